@@ -0,0 +1,412 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: apikeys/apikeys.proto
+
+/*
+Package apikeyspb is a generated protocol buffer package.
+
+It is generated from these files:
+	apikeys/apikeys.proto
+
+It has these top-level messages:
+	APIKey
+	CreateAPIKeyRequest
+	CreateAPIKeyResponse
+	ListAPIKeysRequest
+	ListAPIKeysResponse
+	RevokeAPIKeyRequest
+*/
+package apikeyspb
+
+import proto "github.com/gogo/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import _ "github.com/nathanows/elegant-monolith/_protos/google/api"
+import google_protobuf1 "github.com/gogo/protobuf/types"
+import google_protobuf2 "github.com/gogo/protobuf/types"
+import _ "github.com/gogo/protobuf/gogoproto"
+import _ "github.com/nathanows/elegant-monolith/_protos/options"
+
+import context "golang.org/x/net/context"
+import grpc "google.golang.org/grpc"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+type APIKey struct {
+	ID        int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	CompanyID int64  `protobuf:"varint,2,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	Name      string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// prefix is the non-secret leading part of the key, used to identify it
+	Prefix     string                      `protobuf:"bytes,4,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Scopes     []string                    `protobuf:"bytes,5,rep,name=scopes" json:"scopes,omitempty"`
+	ExpiresAt  *google_protobuf2.Timestamp `protobuf:"bytes,20,opt,name=expires_at,json=expiresAt" json:"expires_at,omitempty"`
+	LastUsedAt *google_protobuf2.Timestamp `protobuf:"bytes,21,opt,name=last_used_at,json=lastUsedAt" json:"last_used_at,omitempty"`
+	RevokedAt  *google_protobuf2.Timestamp `protobuf:"bytes,22,opt,name=revoked_at,json=revokedAt" json:"revoked_at,omitempty"`
+	CreatedAt  *google_protobuf2.Timestamp `protobuf:"bytes,50,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	UpdatedAt  *google_protobuf2.Timestamp `protobuf:"bytes,51,opt,name=updated_at,json=updatedAt" json:"updated_at,omitempty"`
+}
+
+func (m *APIKey) Reset()                    { *m = APIKey{} }
+func (m *APIKey) String() string            { return proto.CompactTextString(m) }
+func (*APIKey) ProtoMessage()               {}
+func (*APIKey) Descriptor() ([]byte, []int) { return fileDescriptorApikeys, []int{0} }
+
+func (m *APIKey) GetID() int64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func (m *APIKey) GetCompanyID() int64 {
+	if m != nil {
+		return m.CompanyID
+	}
+	return 0
+}
+
+func (m *APIKey) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *APIKey) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *APIKey) GetScopes() []string {
+	if m != nil {
+		return m.Scopes
+	}
+	return nil
+}
+
+func (m *APIKey) GetExpiresAt() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+func (m *APIKey) GetLastUsedAt() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.LastUsedAt
+	}
+	return nil
+}
+
+func (m *APIKey) GetRevokedAt() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.RevokedAt
+	}
+	return nil
+}
+
+func (m *APIKey) GetCreatedAt() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+func (m *APIKey) GetUpdatedAt() *google_protobuf2.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+type CreateAPIKeyRequest struct {
+	APIKey *APIKey `protobuf:"bytes,1,opt,name=api_key,json=apiKey" json:"api_key,omitempty"`
+}
+
+func (m *CreateAPIKeyRequest) Reset()                    { *m = CreateAPIKeyRequest{} }
+func (m *CreateAPIKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*CreateAPIKeyRequest) ProtoMessage()               {}
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptorApikeys, []int{1} }
+
+func (m *CreateAPIKeyRequest) GetAPIKey() *APIKey {
+	if m != nil {
+		return m.APIKey
+	}
+	return nil
+}
+
+type CreateAPIKeyResponse struct {
+	APIKey *APIKey `protobuf:"bytes,1,opt,name=api_key,json=apiKey" json:"api_key,omitempty"`
+	// key is the full secret, it is only ever returned on creation
+	Key string `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *CreateAPIKeyResponse) Reset()                    { *m = CreateAPIKeyResponse{} }
+func (m *CreateAPIKeyResponse) String() string            { return proto.CompactTextString(m) }
+func (*CreateAPIKeyResponse) ProtoMessage()               {}
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) { return fileDescriptorApikeys, []int{2} }
+
+func (m *CreateAPIKeyResponse) GetAPIKey() *APIKey {
+	if m != nil {
+		return m.APIKey
+	}
+	return nil
+}
+
+func (m *CreateAPIKeyResponse) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+type ListAPIKeysRequest struct {
+	CompanyID int64 `protobuf:"varint,1,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+}
+
+func (m *ListAPIKeysRequest) Reset()                    { *m = ListAPIKeysRequest{} }
+func (m *ListAPIKeysRequest) String() string            { return proto.CompactTextString(m) }
+func (*ListAPIKeysRequest) ProtoMessage()               {}
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) { return fileDescriptorApikeys, []int{3} }
+
+func (m *ListAPIKeysRequest) GetCompanyID() int64 {
+	if m != nil {
+		return m.CompanyID
+	}
+	return 0
+}
+
+type ListAPIKeysResponse struct {
+	APIKeys []*APIKey `protobuf:"bytes,1,rep,name=api_keys,json=apiKeys" json:"api_keys,omitempty"`
+}
+
+func (m *ListAPIKeysResponse) Reset()                    { *m = ListAPIKeysResponse{} }
+func (m *ListAPIKeysResponse) String() string            { return proto.CompactTextString(m) }
+func (*ListAPIKeysResponse) ProtoMessage()               {}
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptorApikeys, []int{4} }
+
+func (m *ListAPIKeysResponse) GetAPIKeys() []*APIKey {
+	if m != nil {
+		return m.APIKeys
+	}
+	return nil
+}
+
+type RevokeAPIKeyRequest struct {
+	ID int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *RevokeAPIKeyRequest) Reset()                    { *m = RevokeAPIKeyRequest{} }
+func (m *RevokeAPIKeyRequest) String() string            { return proto.CompactTextString(m) }
+func (*RevokeAPIKeyRequest) ProtoMessage()               {}
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) { return fileDescriptorApikeys, []int{5} }
+
+func (m *RevokeAPIKeyRequest) GetID() int64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*APIKey)(nil), "apikeys.APIKey")
+	proto.RegisterType((*CreateAPIKeyRequest)(nil), "apikeys.CreateAPIKeyRequest")
+	proto.RegisterType((*CreateAPIKeyResponse)(nil), "apikeys.CreateAPIKeyResponse")
+	proto.RegisterType((*ListAPIKeysRequest)(nil), "apikeys.ListAPIKeysRequest")
+	proto.RegisterType((*ListAPIKeysResponse)(nil), "apikeys.ListAPIKeysResponse")
+	proto.RegisterType((*RevokeAPIKeyRequest)(nil), "apikeys.RevokeAPIKeyRequest")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for APIKeySvc service
+
+type APIKeySvcClient interface {
+	Create(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error)
+	List(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error)
+	Revoke(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+}
+
+type aPIKeySvcClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAPIKeySvcClient(cc *grpc.ClientConn) APIKeySvcClient {
+	return &aPIKeySvcClient{cc}
+}
+
+func (c *aPIKeySvcClient) Create(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error) {
+	out := new(CreateAPIKeyResponse)
+	err := grpc.Invoke(ctx, "/apikeys.APIKeySvc/Create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIKeySvcClient) List(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error) {
+	out := new(ListAPIKeysResponse)
+	err := grpc.Invoke(ctx, "/apikeys.APIKeySvc/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIKeySvcClient) Revoke(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
+	err := grpc.Invoke(ctx, "/apikeys.APIKeySvc/Revoke", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for APIKeySvc service
+
+type APIKeySvcServer interface {
+	Create(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	List(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error)
+	Revoke(context.Context, *RevokeAPIKeyRequest) (*google_protobuf1.Empty, error)
+}
+
+func RegisterAPIKeySvcServer(s *grpc.Server, srv APIKeySvcServer) {
+	s.RegisterService(&_APIKeySvc_serviceDesc, srv)
+}
+
+func _APIKeySvc_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIKeySvcServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apikeys.APIKeySvc/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIKeySvcServer).Create(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIKeySvc_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAPIKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIKeySvcServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apikeys.APIKeySvc/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIKeySvcServer).List(ctx, req.(*ListAPIKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _APIKeySvc_Revoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIKeySvcServer).Revoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/apikeys.APIKeySvc/Revoke",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIKeySvcServer).Revoke(ctx, req.(*RevokeAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _APIKeySvc_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "apikeys.APIKeySvc",
+	HandlerType: (*APIKeySvcServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _APIKeySvc_Create_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _APIKeySvc_List_Handler,
+		},
+		{
+			MethodName: "Revoke",
+			Handler:    _APIKeySvc_Revoke_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "apikeys/apikeys.proto",
+}
+
+func init() { proto.RegisterFile("apikeys/apikeys.proto", fileDescriptorApikeys) }
+
+var fileDescriptorApikeys = []byte{
+	// 638 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdd, 0x6e, 0x12, 0x4f,
+	0x14, 0xcf, 0x2e, 0xfc, 0xb7, 0xec, 0xa1, 0xe9, 0x5f, 0x87, 0x16, 0x47, 0x8a, 0x81, 0x6c, 0xbc,
+	0x20, 0x35, 0x65, 0x13, 0x6a, 0x62, 0x24, 0xde, 0x40, 0x6b, 0x22, 0xa9, 0x31, 0x75, 0xd5, 0x18,
+	0x35, 0x8a, 0x0b, 0x3b, 0xc5, 0x4d, 0x0b, 0x33, 0x32, 0x43, 0x53, 0xbc, 0xf4, 0x15, 0x7c, 0x01,
+	0xbd, 0xed, 0x2b, 0xf0, 0x18, 0xde, 0xf7, 0x82, 0xe8, 0x7b, 0x98, 0xf9, 0x58, 0x2c, 0x82, 0xc1,
+	0xab, 0xd9, 0x33, 0xbf, 0x8f, 0x39, 0x73, 0xce, 0x99, 0x85, 0xad, 0x90, 0xc5, 0x27, 0x64, 0xcc,
+	0x7d, 0xb3, 0x56, 0xd9, 0x90, 0x0a, 0x8a, 0xd6, 0x4c, 0x58, 0x28, 0xf6, 0x28, 0xed, 0x9d, 0x12,
+	0x09, 0xfb, 0xe1, 0x60, 0x40, 0x45, 0x28, 0x62, 0x3a, 0x30, 0xb4, 0xc2, 0xb6, 0x41, 0x55, 0xd4,
+	0x19, 0x1d, 0xfb, 0xa4, 0xcf, 0xc4, 0xd8, 0x80, 0xa5, 0x3f, 0x41, 0x11, 0xf7, 0x09, 0x17, 0x61,
+	0x9f, 0x19, 0xc2, 0x6e, 0x2f, 0x16, 0x1f, 0x46, 0x9d, 0x6a, 0x97, 0xf6, 0xfd, 0x1e, 0xed, 0xd1,
+	0xdf, 0x4c, 0x19, 0xa9, 0x40, 0x7d, 0x19, 0xfa, 0x16, 0x65, 0xea, 0x6c, 0xdf, 0xac, 0x7a, 0xdb,
+	0xfb, 0x99, 0x02, 0xa7, 0x71, 0xd4, 0x3a, 0x24, 0x63, 0x94, 0x07, 0x3b, 0x8e, 0xb0, 0x55, 0xb6,
+	0x2a, 0xa9, 0xa6, 0x33, 0xbd, 0x2c, 0xd9, 0xad, 0x83, 0xc0, 0x8e, 0x23, 0x54, 0x03, 0xe8, 0xd2,
+	0x3e, 0x0b, 0x07, 0xe3, 0x76, 0x1c, 0x61, 0x5b, 0xe1, 0xb9, 0x8b, 0x09, 0xb6, 0x33, 0xd6, 0xf4,
+	0xb2, 0xe4, 0xee, 0x6b, 0xac, 0x75, 0x10, 0xb8, 0x86, 0xd6, 0x8a, 0x50, 0x11, 0xd2, 0x83, 0xb0,
+	0x4f, 0x70, 0xaa, 0x6c, 0x55, 0xdc, 0x66, 0xe6, 0x62, 0x82, 0xd3, 0x19, 0x0b, 0x1f, 0x05, 0x6a,
+	0x17, 0xe5, 0xc1, 0x61, 0x43, 0x72, 0x1c, 0x9f, 0xe3, 0xb4, 0xc4, 0x03, 0x13, 0x21, 0x1f, 0x1c,
+	0xde, 0xa5, 0x8c, 0x70, 0xfc, 0x5f, 0x39, 0x55, 0x71, 0x9b, 0x37, 0x2e, 0x26, 0x38, 0xe7, 0x5d,
+	0x7f, 0xf7, 0x26, 0xdc, 0xfd, 0xd4, 0x7e, 0x7b, 0xa7, 0x6e, 0xd6, 0xdb, 0x81, 0xa1, 0xa1, 0xfb,
+	0x00, 0xe4, 0x9c, 0xc5, 0x43, 0xc2, 0xdb, 0xa1, 0xc0, 0x9b, 0x65, 0xab, 0x92, 0xad, 0x15, 0xaa,
+	0xba, 0x72, 0xd5, 0xa4, 0x1e, 0xd5, 0xe7, 0x49, 0xe5, 0x02, 0xd7, 0xb0, 0x1b, 0x02, 0x3d, 0x80,
+	0xf5, 0xd3, 0x90, 0x8b, 0xf6, 0x88, 0x93, 0x48, 0x8a, 0xb7, 0x56, 0x8a, 0x41, 0xf2, 0x5f, 0x70,
+	0x12, 0x35, 0x84, 0x3c, 0x78, 0x48, 0xce, 0xe8, 0x89, 0xd6, 0xe6, 0x57, 0x1f, 0x6c, 0xd8, 0x5a,
+	0xda, 0x1d, 0x92, 0x50, 0x68, 0x69, 0x6d, 0xb5, 0xd4, 0xb0, 0xb5, 0x74, 0xc4, 0xa2, 0x44, 0xba,
+	0xb7, 0x5a, 0x6a, 0xd8, 0x0d, 0xe1, 0x3d, 0x85, 0xdc, 0xbe, 0xf2, 0xd1, 0xcd, 0x0e, 0xc8, 0xc7,
+	0x11, 0xe1, 0x02, 0xd5, 0x41, 0xce, 0x6a, 0xfb, 0x84, 0x8c, 0x55, 0xe3, 0xb3, 0xb5, 0xff, 0xab,
+	0xc9, 0x28, 0x6b, 0x62, 0xf3, 0xda, 0xac, 0xd3, 0x66, 0x4e, 0x02, 0x27, 0x64, 0xf1, 0x21, 0x19,
+	0x7b, 0x11, 0x6c, 0xce, 0x5b, 0x72, 0x46, 0x07, 0x9c, 0xa0, 0xbb, 0x2b, 0x3d, 0x61, 0xd1, 0x0d,
+	0xe5, 0x21, 0x25, 0x15, 0xb6, 0x1a, 0x98, 0xf4, 0xd7, 0x09, 0xb6, 0x02, 0xb9, 0xe1, 0x3d, 0x02,
+	0xf4, 0x38, 0xe6, 0x42, 0xb3, 0x79, 0x92, 0xf7, 0xfc, 0x4c, 0x5a, 0xff, 0x32, 0x93, 0xde, 0x13,
+	0xc8, 0xcd, 0x39, 0x99, 0x74, 0xef, 0x41, 0xc6, 0xa4, 0xcb, 0xb1, 0x55, 0x4e, 0x2d, 0xcb, 0x37,
+	0x3b, 0xbd, 0x2c, 0xad, 0x25, 0xba, 0x35, 0x9d, 0x30, 0xf7, 0xf6, 0x20, 0x17, 0xa8, 0xae, 0xce,
+	0x97, 0xb4, 0x78, 0xe5, 0x19, 0xad, 0xcf, 0x52, 0x32, 0x8f, 0xa9, 0xf6, 0xcd, 0x06, 0x57, 0xf3,
+	0x9f, 0x9d, 0x75, 0xd1, 0x7b, 0x70, 0x74, 0x09, 0x51, 0x71, 0x76, 0xe6, 0x92, 0x36, 0x15, 0x6e,
+	0xfd, 0x05, 0xd5, 0x57, 0xf0, 0x6e, 0x7e, 0xfe, 0xfe, 0xe3, 0x8b, 0x9d, 0xf3, 0x36, 0xcc, 0x7f,
+	0xc8, 0xd7, 0x23, 0x53, 0xb7, 0x76, 0xd0, 0x4b, 0x48, 0xcb, 0x4b, 0xa3, 0xed, 0x99, 0xc3, 0x62,
+	0x35, 0x0b, 0xc5, 0xe5, 0xa0, 0x71, 0xdf, 0x54, 0xee, 0x1b, 0x68, 0x3d, 0x71, 0x3f, 0x95, 0x86,
+	0xaf, 0xc0, 0xd1, 0xb7, 0xbf, 0x92, 0xfa, 0x92, 0x72, 0x14, 0xf2, 0x0b, 0xf3, 0xf9, 0x50, 0xfe,
+	0xe5, 0x16, 0x73, 0xd6, 0x2f, 0xa4, 0x6e, 0xed, 0x34, 0xb3, 0xaf, 0x5d, 0xe3, 0xc8, 0x3a, 0x1d,
+	0x47, 0xe9, 0xf6, 0x7e, 0x0d, 0x00, 0x68, 0x7e, 0x5c, 0x66, 0x6b, 0x05, 0x00, 0x00,
+}
@@ -6,8 +6,10 @@ Package codes is a generated protocol buffer package.
 
 It is generated from these files:
 	code/code.proto
+	code/error_details.proto
 
 It has these top-level messages:
+	ErrorInfo
 */
 package codes
 
@@ -28,7 +30,6 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 // The canonical error codes.
 //
-//
 // Sometimes multiple error codes may apply.  Services should return
 // the most specific error code that applies.  For example, prefer
 // `OUT_OF_RANGE` over `FAILED_PRECONDITION` if both codes apply.
@@ -225,7 +226,7 @@ func init() {
 func init() { proto.RegisterFile("code/code.proto", fileDescriptorCode) }
 
 var fileDescriptorCode = []byte{
-	// 298 bytes of a gzipped FileDescriptorProto
+	// 294 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x1c, 0x90, 0x49, 0x92, 0x5a, 0x31,
 	0x0c, 0x86, 0x13, 0xc2, 0x28, 0x86, 0x27, 0x44, 0x86, 0x3b, 0x64, 0x41, 0x16, 0x39, 0x81, 0x78,
 	0x16, 0xe0, 0xc2, 0xc8, 0x94, 0x07, 0x42, 0xb2, 0x71, 0x55, 0x80, 0xf5, 0xeb, 0xa2, 0xfb, 0x6e,
@@ -243,6 +244,6 @@ var fileDescriptorCode = []byte{
 	0x99, 0xcf, 0xa9, 0xf8, 0x6d, 0x09, 0xac, 0x3b, 0xc1, 0x29, 0x2d, 0x61, 0x9e, 0xd5, 0x1e, 0x4f,
 	0x4e, 0x2a, 0x86, 0x18, 0x9c, 0xd1, 0x0c, 0xc6, 0x56, 0x93, 0x04, 0x65, 0x87, 0x73, 0x6a, 0x60,
 	0x9a, 0x95, 0xcf, 0x6c, 0x1d, 0x6f, 0x9c, 0xe0, 0xa2, 0x02, 0x19, 0x4e, 0x5c, 0x9c, 0x8f, 0x11,
-	0x9b, 0xcd, 0xe8, 0xdf, 0xe0, 0xa9, 0xf0, 0xff, 0xf0, 0x69, 0xf5, 0xf7, 0x47, 0x00, 0x00, 0x00,
-	0xff, 0xff, 0x39, 0x75, 0xbc, 0xf1, 0x68, 0x01, 0x00, 0x00,
+	0x9b, 0xcd, 0xe8, 0xdf, 0xe0, 0xa9, 0xf0, 0xff, 0xf0, 0x69, 0xf5, 0xf7, 0xc7, 0x00, 0x39, 0x75,
+	0xbc, 0xf1, 0x68, 0x01, 0x00, 0x00,
 }
@@ -0,0 +1,66 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: code/error_details.proto
+
+package codes
+
+import proto "github.com/gogo/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// ErrorInfo describes the cause of an error in a machine readable way. It is
+// attached to gRPC statuses next to the standard google.rpc details.
+type ErrorInfo struct {
+	// The reason of the error, a stable UPPER_SNAKE_CASE identifier clients
+	// may branch on, e.g. COMPANY_NAME_TAKEN. Unique within the error's code.
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The validation rules broken by the fields listed in the BadRequest
+	// details, keyed by field path, e.g. company.name: required. Multiple rules
+	// broken by one field are comma separated.
+	FieldRules map[string]string `protobuf:"bytes,2,rep,name=field_rules,json=fieldRules" json:"field_rules,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (m *ErrorInfo) Reset()                    { *m = ErrorInfo{} }
+func (m *ErrorInfo) String() string            { return proto.CompactTextString(m) }
+func (*ErrorInfo) ProtoMessage()               {}
+func (*ErrorInfo) Descriptor() ([]byte, []int) { return fileDescriptorErrorDetails, []int{0} }
+
+func (m *ErrorInfo) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *ErrorInfo) GetFieldRules() map[string]string {
+	if m != nil {
+		return m.FieldRules
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*ErrorInfo)(nil), "core.codes.ErrorInfo")
+}
+
+func init() { proto.RegisterFile("code/error_details.proto", fileDescriptorErrorDetails) }
+
+var fileDescriptorErrorDetails = []byte{
+	// 181 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x48, 0xce, 0x4f, 0x49,
+	0xd5, 0x4f, 0x2d, 0x2a, 0xca, 0x2f, 0x8a, 0x4f, 0x49, 0x2d, 0x49, 0xcc, 0xcc, 0x29, 0xd6, 0x2b,
+	0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x4a, 0xce, 0x2f, 0x4a, 0xd5, 0x03, 0x49, 0x17, 0x2b, 0xad,
+	0x62, 0xe4, 0xe2, 0x74, 0x05, 0xa9, 0xf1, 0xcc, 0x4b, 0xcb, 0x17, 0x12, 0xe3, 0x62, 0x2b, 0x4a,
+	0x4d, 0x2c, 0xce, 0xcf, 0x93, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0c, 0x82, 0xf2, 0x84, 0xdc, 0xb8,
+	0xb8, 0xd3, 0x32, 0x53, 0x73, 0x52, 0xe2, 0x8b, 0x4a, 0x73, 0x52, 0x8b, 0x25, 0x98, 0x14, 0x98,
+	0x35, 0xb8, 0x8d, 0x54, 0xf5, 0x10, 0xe6, 0xe8, 0xc1, 0xcd, 0xd0, 0x73, 0x03, 0x29, 0x0c, 0x02,
+	0xa9, 0x73, 0xcd, 0x2b, 0x29, 0xaa, 0x0c, 0xe2, 0x4a, 0x83, 0x0b, 0x48, 0xd9, 0x72, 0xf1, 0xa3,
+	0x49, 0x0b, 0x09, 0x70, 0x31, 0x67, 0xa7, 0x56, 0x42, 0xed, 0x03, 0x31, 0x85, 0x44, 0xb8, 0x58,
+	0xcb, 0x12, 0x73, 0x4a, 0x53, 0x25, 0x98, 0xc0, 0x62, 0x10, 0x8e, 0x15, 0x93, 0x05, 0xa3, 0x13,
+	0x7b, 0x14, 0x2b, 0xd8, 0xb6, 0x24, 0x36, 0xb0, 0x47, 0x8c, 0x01, 0x03, 0x00, 0x50, 0x73, 0xea,
+	0xcb, 0xe4, 0x00, 0x00, 0x00,
+}
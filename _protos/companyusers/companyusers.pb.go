@@ -12,16 +12,25 @@ It has these top-level messages:
 	Company
 	CompanyUser
 	Pagination
+	Highlight
 	SaveUserRequest
 	FindUserRequest
 	FindAllUsersRequest
 	FindAllUsersResponse
 	DeleteUserRequest
+	SearchUsersRequest
+	UserSearchResult
+	SearchUsersResponse
+	UndeleteUserRequest
 	SaveCompanyRequest
 	FindCompanyRequest
 	FindAllCompaniesRequest
 	FindAllCompaniesResponse
 	DeleteCompanyRequest
+	SearchCompaniesRequest
+	CompanySearchResult
+	SearchCompaniesResponse
+	UndeleteCompanyRequest
 	SaveCompanyUserRequest
 	FindCompanyUserRequest
 	FindAllCompanyUsersRequest
@@ -35,9 +44,12 @@ package companyuserspb
 import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
-import google_protobuf "github.com/gogo/protobuf/types"
+import _ "github.com/nathanows/elegant-monolith/_protos/google/api"
 import google_protobuf1 "github.com/gogo/protobuf/types"
+import google_protobuf2 "github.com/gogo/protobuf/types"
+import google_protobuf3 "github.com/gogo/protobuf/types"
 import _ "github.com/gogo/protobuf/gogoproto"
+import _ "github.com/nathanows/elegant-monolith/_protos/options"
 
 import context "golang.org/x/net/context"
 import grpc "google.golang.org/grpc"
@@ -58,8 +70,16 @@ type User struct {
 	FirstName string                      `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
 	LastName  string                      `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
 	Email     string                      `protobuf:"bytes,4,opt,name=email,proto3" json:"email,omitempty"`
-	CreatedAt *google_protobuf1.Timestamp `protobuf:"bytes,50,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
-	UpdatedAt *google_protobuf1.Timestamp `protobuf:"bytes,51,opt,name=updated_at,json=updatedAt" json:"updated_at,omitempty"`
+	CreatedAt *google_protobuf3.Timestamp `protobuf:"bytes,50,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	UpdatedAt *google_protobuf3.Timestamp `protobuf:"bytes,51,opt,name=updated_at,json=updatedAt" json:"updated_at,omitempty"`
+	// etag identifies the version of the user. Set it on updates to only apply
+	// them to that version, a stale etag fails with ABORTED. Over HTTP it is
+	// also sent as the ETag header and accepted as If-Match.
+	Etag string `protobuf:"bytes,52,opt,name=etag,proto3" json:"etag,omitempty"`
+	// deleted_at is set once the user is deleted. Deleted users are
+	// hidden unless show_deleted is set, can be restored with Undelete and
+	// are purged after a retention period.
+	DeletedAt *google_protobuf3.Timestamp `protobuf:"bytes,53,opt,name=deleted_at,json=deletedAt" json:"deleted_at,omitempty"`
 }
 
 func (m *User) Reset()                    { *m = User{} }
@@ -95,25 +115,47 @@ func (m *User) GetEmail() string {
 	return ""
 }
 
-func (m *User) GetCreatedAt() *google_protobuf1.Timestamp {
+func (m *User) GetCreatedAt() *google_protobuf3.Timestamp {
 	if m != nil {
 		return m.CreatedAt
 	}
 	return nil
 }
 
-func (m *User) GetUpdatedAt() *google_protobuf1.Timestamp {
+func (m *User) GetUpdatedAt() *google_protobuf3.Timestamp {
 	if m != nil {
 		return m.UpdatedAt
 	}
 	return nil
 }
 
+func (m *User) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *User) GetDeletedAt() *google_protobuf3.Timestamp {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return nil
+}
+
 type Company struct {
 	ID        int64                       `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name      string                      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	CreatedAt *google_protobuf1.Timestamp `protobuf:"bytes,50,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
-	UpdatedAt *google_protobuf1.Timestamp `protobuf:"bytes,51,opt,name=updated_at,json=updatedAt" json:"updated_at,omitempty"`
+	CreatedAt *google_protobuf3.Timestamp `protobuf:"bytes,50,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	UpdatedAt *google_protobuf3.Timestamp `protobuf:"bytes,51,opt,name=updated_at,json=updatedAt" json:"updated_at,omitempty"`
+	// etag identifies the version of the company. Set it on updates to only apply
+	// them to that version, a stale etag fails with ABORTED. Over HTTP it is
+	// also sent as the ETag header and accepted as If-Match.
+	Etag string `protobuf:"bytes,52,opt,name=etag,proto3" json:"etag,omitempty"`
+	// deleted_at is set once the company is deleted. Deleted companies are
+	// hidden unless show_deleted is set, can be restored with Undelete and
+	// are purged after a retention period.
+	DeletedAt *google_protobuf3.Timestamp `protobuf:"bytes,53,opt,name=deleted_at,json=deletedAt" json:"deleted_at,omitempty"`
 }
 
 func (m *Company) Reset()                    { *m = Company{} }
@@ -135,25 +177,39 @@ func (m *Company) GetName() string {
 	return ""
 }
 
-func (m *Company) GetCreatedAt() *google_protobuf1.Timestamp {
+func (m *Company) GetCreatedAt() *google_protobuf3.Timestamp {
 	if m != nil {
 		return m.CreatedAt
 	}
 	return nil
 }
 
-func (m *Company) GetUpdatedAt() *google_protobuf1.Timestamp {
+func (m *Company) GetUpdatedAt() *google_protobuf3.Timestamp {
 	if m != nil {
 		return m.UpdatedAt
 	}
 	return nil
 }
 
+func (m *Company) GetEtag() string {
+	if m != nil {
+		return m.Etag
+	}
+	return ""
+}
+
+func (m *Company) GetDeletedAt() *google_protobuf3.Timestamp {
+	if m != nil {
+		return m.DeletedAt
+	}
+	return nil
+}
+
 type CompanyUser struct {
 	CompanyID int64                       `protobuf:"varint,1,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
 	UserID    int64                       `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	CreatedAt *google_protobuf1.Timestamp `protobuf:"bytes,50,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
-	UpdatedAt *google_protobuf1.Timestamp `protobuf:"bytes,51,opt,name=updated_at,json=updatedAt" json:"updated_at,omitempty"`
+	CreatedAt *google_protobuf3.Timestamp `protobuf:"bytes,50,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	UpdatedAt *google_protobuf3.Timestamp `protobuf:"bytes,51,opt,name=updated_at,json=updatedAt" json:"updated_at,omitempty"`
 }
 
 func (m *CompanyUser) Reset()                    { *m = CompanyUser{} }
@@ -175,23 +231,37 @@ func (m *CompanyUser) GetUserID() int64 {
 	return 0
 }
 
-func (m *CompanyUser) GetCreatedAt() *google_protobuf1.Timestamp {
+func (m *CompanyUser) GetCreatedAt() *google_protobuf3.Timestamp {
 	if m != nil {
 		return m.CreatedAt
 	}
 	return nil
 }
 
-func (m *CompanyUser) GetUpdatedAt() *google_protobuf1.Timestamp {
+func (m *CompanyUser) GetUpdatedAt() *google_protobuf3.Timestamp {
 	if m != nil {
 		return m.UpdatedAt
 	}
 	return nil
 }
 
+// Pagination selects a page of a listing. Pages are cut by keyset, so
+// results created or deleted between calls don't shift the following pages.
 type Pagination struct {
+	// page_number, counted from 1, and results_per_page page by offset.
+	// Deprecated: use page_size and page_token. page_number is ignored along
+	// with a page_token, results_per_page when page_size is set.
 	PageNumber     int32 `protobuf:"varint,1,opt,name=page_number,json=pageNumber,proto3" json:"page_number,omitempty"`
 	ResultsPerPage int32 `protobuf:"varint,2,opt,name=results_per_page,json=resultsPerPage,proto3" json:"results_per_page,omitempty"`
+	// page_size is the maximum number of results to return, zero for the
+	// server default. The server caps it at its maximum page size.
+	PageSize int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous page, empty for the
+	// first page. It is opaque and only valid for the listing it came from.
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// include_total_count asks for the total number of results, which takes
+	// an extra query
+	IncludeTotalCount bool `protobuf:"varint,5,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
 }
 
 func (m *Pagination) Reset()                    { *m = Pagination{} }
@@ -213,14 +283,65 @@ func (m *Pagination) GetResultsPerPage() int32 {
 	return 0
 }
 
+func (m *Pagination) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *Pagination) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *Pagination) GetIncludeTotalCount() bool {
+	if m != nil {
+		return m.IncludeTotalCount
+	}
+	return false
+}
+
+// Highlight is a field of a search result with the words matching the query
+// wrapped in <em> tags. The value isn't HTML escaped.
+type Highlight struct {
+	Field   string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Snippet string `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+}
+
+func (m *Highlight) Reset()                    { *m = Highlight{} }
+func (m *Highlight) String() string            { return proto.CompactTextString(m) }
+func (*Highlight) ProtoMessage()               {}
+func (*Highlight) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{4} }
+
+func (m *Highlight) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *Highlight) GetSnippet() string {
+	if m != nil {
+		return m.Snippet
+	}
+	return ""
+}
+
 type SaveUserRequest struct {
 	User *User `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
+	// update_mask lists the fields of user an update sets, e.g. "email". The
+	// others are left untouched. Every mutable field is set when it is empty
+	// or "*". id and created_at can't be updated.
+	UpdateMask *google_protobuf2.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask" json:"update_mask,omitempty"`
 }
 
 func (m *SaveUserRequest) Reset()                    { *m = SaveUserRequest{} }
 func (m *SaveUserRequest) String() string            { return proto.CompactTextString(m) }
 func (*SaveUserRequest) ProtoMessage()               {}
-func (*SaveUserRequest) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{4} }
+func (*SaveUserRequest) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{5} }
 
 func (m *SaveUserRequest) GetUser() *User {
 	if m != nil {
@@ -229,14 +350,23 @@ func (m *SaveUserRequest) GetUser() *User {
 	return nil
 }
 
+func (m *SaveUserRequest) GetUpdateMask() *google_protobuf2.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
 type FindUserRequest struct {
 	ID int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// show_deleted finds the user even if it was deleted
+	ShowDeleted bool `protobuf:"varint,2,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
 }
 
 func (m *FindUserRequest) Reset()                    { *m = FindUserRequest{} }
 func (m *FindUserRequest) String() string            { return proto.CompactTextString(m) }
 func (*FindUserRequest) ProtoMessage()               {}
-func (*FindUserRequest) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{5} }
+func (*FindUserRequest) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{6} }
 
 func (m *FindUserRequest) GetID() int64 {
 	if m != nil {
@@ -245,14 +375,51 @@ func (m *FindUserRequest) GetID() int64 {
 	return 0
 }
 
+func (m *FindUserRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
+}
+
 type FindAllUsersRequest struct {
-	Pagination *Pagination `protobuf:"bytes,50,opt,name=pagination" json:"pagination,omitempty"`
+	// filter is an AIP-160 expression over id, first_name, last_name, email,
+	// created_at and updated_at, e.g. email:"*@acme.com" AND created_at > "2024-01-01"
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// order_by is a comma separated list of id, first_name, last_name, email,
+	// created_at and updated_at, each optionally followed by desc, e.g.
+	// "last_name, first_name"
+	OrderBy string `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// show_deleted includes deleted users
+	ShowDeleted bool        `protobuf:"varint,4,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	Pagination  *Pagination `protobuf:"bytes,50,opt,name=pagination" json:"pagination,omitempty"`
 }
 
 func (m *FindAllUsersRequest) Reset()                    { *m = FindAllUsersRequest{} }
 func (m *FindAllUsersRequest) String() string            { return proto.CompactTextString(m) }
 func (*FindAllUsersRequest) ProtoMessage()               {}
-func (*FindAllUsersRequest) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{6} }
+func (*FindAllUsersRequest) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{7} }
+
+func (m *FindAllUsersRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *FindAllUsersRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+func (m *FindAllUsersRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
+}
 
 func (m *FindAllUsersRequest) GetPagination() *Pagination {
 	if m != nil {
@@ -262,14 +429,22 @@ func (m *FindAllUsersRequest) GetPagination() *Pagination {
 }
 
 type FindAllUsersResponse struct {
-	Users      []*User     `protobuf:"bytes,1,rep,name=users" json:"users,omitempty"`
+	Users []*User `protobuf:"bytes,1,rep,name=users" json:"users,omitempty"`
+	// pagination echoes the page_number and results_per_page of the page.
+	// Deprecated: use next_page_token.
 	Pagination *Pagination `protobuf:"bytes,50,opt,name=pagination" json:"pagination,omitempty"`
+	// next_page_token fetches the next page, empty on the last one
+	NextPageToken string `protobuf:"bytes,51,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_count is only set when include_total_count was asked for
+	TotalCount int64 `protobuf:"varint,52,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
 }
 
-func (m *FindAllUsersResponse) Reset()                    { *m = FindAllUsersResponse{} }
-func (m *FindAllUsersResponse) String() string            { return proto.CompactTextString(m) }
-func (*FindAllUsersResponse) ProtoMessage()               {}
-func (*FindAllUsersResponse) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{7} }
+func (m *FindAllUsersResponse) Reset()         { *m = FindAllUsersResponse{} }
+func (m *FindAllUsersResponse) String() string { return proto.CompactTextString(m) }
+func (*FindAllUsersResponse) ProtoMessage()    {}
+func (*FindAllUsersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorCompanyusers, []int{8}
+}
 
 func (m *FindAllUsersResponse) GetUsers() []*User {
 	if m != nil {
@@ -285,6 +460,20 @@ func (m *FindAllUsersResponse) GetPagination() *Pagination {
 	return nil
 }
 
+func (m *FindAllUsersResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func (m *FindAllUsersResponse) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
 type DeleteUserRequest struct {
 	ID int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
@@ -292,7 +481,7 @@ type DeleteUserRequest struct {
 func (m *DeleteUserRequest) Reset()                    { *m = DeleteUserRequest{} }
 func (m *DeleteUserRequest) String() string            { return proto.CompactTextString(m) }
 func (*DeleteUserRequest) ProtoMessage()               {}
-func (*DeleteUserRequest) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{8} }
+func (*DeleteUserRequest) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{9} }
 
 func (m *DeleteUserRequest) GetID() int64 {
 	if m != nil {
@@ -301,14 +490,115 @@ func (m *DeleteUserRequest) GetID() int64 {
 	return 0
 }
 
+// SearchUsersRequest looks up users by name or email. Partial words and
+// misspellings are tolerated, results are ranked by relevance.
+type SearchUsersRequest struct {
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// page_size is the maximum number of results to return, zero for the
+	// server default. The server caps it at its maximum page size.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+}
+
+func (m *SearchUsersRequest) Reset()                    { *m = SearchUsersRequest{} }
+func (m *SearchUsersRequest) String() string            { return proto.CompactTextString(m) }
+func (*SearchUsersRequest) ProtoMessage()               {}
+func (*SearchUsersRequest) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{10} }
+
+func (m *SearchUsersRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchUsersRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+type UserSearchResult struct {
+	User *User `protobuf:"bytes,1,opt,name=user" json:"user,omitempty"`
+	// score ranks the results, higher is more relevant
+	Score      float64      `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Highlights []*Highlight `protobuf:"bytes,3,rep,name=highlights" json:"highlights,omitempty"`
+}
+
+func (m *UserSearchResult) Reset()                    { *m = UserSearchResult{} }
+func (m *UserSearchResult) String() string            { return proto.CompactTextString(m) }
+func (*UserSearchResult) ProtoMessage()               {}
+func (*UserSearchResult) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{11} }
+
+func (m *UserSearchResult) GetUser() *User {
+	if m != nil {
+		return m.User
+	}
+	return nil
+}
+
+func (m *UserSearchResult) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *UserSearchResult) GetHighlights() []*Highlight {
+	if m != nil {
+		return m.Highlights
+	}
+	return nil
+}
+
+type SearchUsersResponse struct {
+	Results []*UserSearchResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *SearchUsersResponse) Reset()         { *m = SearchUsersResponse{} }
+func (m *SearchUsersResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchUsersResponse) ProtoMessage()    {}
+func (*SearchUsersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorCompanyusers, []int{12}
+}
+
+func (m *SearchUsersResponse) GetResults() []*UserSearchResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type UndeleteUserRequest struct {
+	ID int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *UndeleteUserRequest) Reset()         { *m = UndeleteUserRequest{} }
+func (m *UndeleteUserRequest) String() string { return proto.CompactTextString(m) }
+func (*UndeleteUserRequest) ProtoMessage()    {}
+func (*UndeleteUserRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorCompanyusers, []int{13}
+}
+
+func (m *UndeleteUserRequest) GetID() int64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
 type SaveCompanyRequest struct {
 	Company *Company `protobuf:"bytes,1,opt,name=company" json:"company,omitempty"`
+	// update_mask lists the fields of company an update sets, e.g. "name". The
+	// others are left untouched. Every mutable field is set when it is empty
+	// or "*". id and created_at can't be updated.
+	UpdateMask *google_protobuf2.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask" json:"update_mask,omitempty"`
 }
 
 func (m *SaveCompanyRequest) Reset()                    { *m = SaveCompanyRequest{} }
 func (m *SaveCompanyRequest) String() string            { return proto.CompactTextString(m) }
 func (*SaveCompanyRequest) ProtoMessage()               {}
-func (*SaveCompanyRequest) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{9} }
+func (*SaveCompanyRequest) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{14} }
 
 func (m *SaveCompanyRequest) GetCompany() *Company {
 	if m != nil {
@@ -317,14 +607,23 @@ func (m *SaveCompanyRequest) GetCompany() *Company {
 	return nil
 }
 
+func (m *SaveCompanyRequest) GetUpdateMask() *google_protobuf2.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
 type FindCompanyRequest struct {
 	ID int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// show_deleted finds the company even if it was deleted
+	ShowDeleted bool `protobuf:"varint,2,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
 }
 
 func (m *FindCompanyRequest) Reset()                    { *m = FindCompanyRequest{} }
 func (m *FindCompanyRequest) String() string            { return proto.CompactTextString(m) }
 func (*FindCompanyRequest) ProtoMessage()               {}
-func (*FindCompanyRequest) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{10} }
+func (*FindCompanyRequest) Descriptor() ([]byte, []int) { return fileDescriptorCompanyusers, []int{15} }
 
 func (m *FindCompanyRequest) GetID() int64 {
 	if m != nil {
@@ -333,15 +632,51 @@ func (m *FindCompanyRequest) GetID() int64 {
 	return 0
 }
 
+func (m *FindCompanyRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
+}
+
 type FindAllCompaniesRequest struct {
-	Pagination *Pagination `protobuf:"bytes,50,opt,name=pagination" json:"pagination,omitempty"`
+	// filter is an AIP-160 expression over id, name, created_at and
+	// updated_at, e.g. name:"acme*" AND created_at > "2024-01-01"
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// order_by is a comma separated list of id, name, created_at and
+	// updated_at, each optionally followed by desc, e.g. "name desc, created_at"
+	OrderBy string `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// show_deleted includes deleted companies
+	ShowDeleted bool        `protobuf:"varint,4,opt,name=show_deleted,json=showDeleted,proto3" json:"show_deleted,omitempty"`
+	Pagination  *Pagination `protobuf:"bytes,50,opt,name=pagination" json:"pagination,omitempty"`
 }
 
 func (m *FindAllCompaniesRequest) Reset()         { *m = FindAllCompaniesRequest{} }
 func (m *FindAllCompaniesRequest) String() string { return proto.CompactTextString(m) }
 func (*FindAllCompaniesRequest) ProtoMessage()    {}
 func (*FindAllCompaniesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorCompanyusers, []int{11}
+	return fileDescriptorCompanyusers, []int{16}
+}
+
+func (m *FindAllCompaniesRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *FindAllCompaniesRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+func (m *FindAllCompaniesRequest) GetShowDeleted() bool {
+	if m != nil {
+		return m.ShowDeleted
+	}
+	return false
 }
 
 func (m *FindAllCompaniesRequest) GetPagination() *Pagination {
@@ -352,15 +687,21 @@ func (m *FindAllCompaniesRequest) GetPagination() *Pagination {
 }
 
 type FindAllCompaniesResponse struct {
-	Companies  []*Company  `protobuf:"bytes,1,rep,name=companies" json:"companies,omitempty"`
+	Companies []*Company `protobuf:"bytes,1,rep,name=companies" json:"companies,omitempty"`
+	// pagination echoes the page_number and results_per_page of the page.
+	// Deprecated: use next_page_token.
 	Pagination *Pagination `protobuf:"bytes,50,opt,name=pagination" json:"pagination,omitempty"`
+	// next_page_token fetches the next page, empty on the last one
+	NextPageToken string `protobuf:"bytes,51,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_count is only set when include_total_count was asked for
+	TotalCount int64 `protobuf:"varint,52,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
 }
 
 func (m *FindAllCompaniesResponse) Reset()         { *m = FindAllCompaniesResponse{} }
 func (m *FindAllCompaniesResponse) String() string { return proto.CompactTextString(m) }
 func (*FindAllCompaniesResponse) ProtoMessage()    {}
 func (*FindAllCompaniesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorCompanyusers, []int{12}
+	return fileDescriptorCompanyusers, []int{17}
 }
 
 func (m *FindAllCompaniesResponse) GetCompanies() []*Company {
@@ -377,6 +718,20 @@ func (m *FindAllCompaniesResponse) GetPagination() *Pagination {
 	return nil
 }
 
+func (m *FindAllCompaniesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func (m *FindAllCompaniesResponse) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
 type DeleteCompanyRequest struct {
 	ID int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
@@ -385,7 +740,7 @@ func (m *DeleteCompanyRequest) Reset()         { *m = DeleteCompanyRequest{} }
 func (m *DeleteCompanyRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteCompanyRequest) ProtoMessage()    {}
 func (*DeleteCompanyRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorCompanyusers, []int{13}
+	return fileDescriptorCompanyusers, []int{18}
 }
 
 func (m *DeleteCompanyRequest) GetID() int64 {
@@ -395,6 +750,107 @@ func (m *DeleteCompanyRequest) GetID() int64 {
 	return 0
 }
 
+// SearchCompaniesRequest looks up companies by name. Partial words and
+// misspellings are tolerated, results are ranked by relevance.
+type SearchCompaniesRequest struct {
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// page_size is the maximum number of results to return, zero for the
+	// server default. The server caps it at its maximum page size.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+}
+
+func (m *SearchCompaniesRequest) Reset()         { *m = SearchCompaniesRequest{} }
+func (m *SearchCompaniesRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchCompaniesRequest) ProtoMessage()    {}
+func (*SearchCompaniesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorCompanyusers, []int{19}
+}
+
+func (m *SearchCompaniesRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchCompaniesRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+type CompanySearchResult struct {
+	Company *Company `protobuf:"bytes,1,opt,name=company" json:"company,omitempty"`
+	// score ranks the results, higher is more relevant
+	Score      float64      `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Highlights []*Highlight `protobuf:"bytes,3,rep,name=highlights" json:"highlights,omitempty"`
+}
+
+func (m *CompanySearchResult) Reset()         { *m = CompanySearchResult{} }
+func (m *CompanySearchResult) String() string { return proto.CompactTextString(m) }
+func (*CompanySearchResult) ProtoMessage()    {}
+func (*CompanySearchResult) Descriptor() ([]byte, []int) {
+	return fileDescriptorCompanyusers, []int{20}
+}
+
+func (m *CompanySearchResult) GetCompany() *Company {
+	if m != nil {
+		return m.Company
+	}
+	return nil
+}
+
+func (m *CompanySearchResult) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *CompanySearchResult) GetHighlights() []*Highlight {
+	if m != nil {
+		return m.Highlights
+	}
+	return nil
+}
+
+type SearchCompaniesResponse struct {
+	Results []*CompanySearchResult `protobuf:"bytes,1,rep,name=results" json:"results,omitempty"`
+}
+
+func (m *SearchCompaniesResponse) Reset()         { *m = SearchCompaniesResponse{} }
+func (m *SearchCompaniesResponse) String() string { return proto.CompactTextString(m) }
+func (*SearchCompaniesResponse) ProtoMessage()    {}
+func (*SearchCompaniesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptorCompanyusers, []int{21}
+}
+
+func (m *SearchCompaniesResponse) GetResults() []*CompanySearchResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type UndeleteCompanyRequest struct {
+	ID int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (m *UndeleteCompanyRequest) Reset()         { *m = UndeleteCompanyRequest{} }
+func (m *UndeleteCompanyRequest) String() string { return proto.CompactTextString(m) }
+func (*UndeleteCompanyRequest) ProtoMessage()    {}
+func (*UndeleteCompanyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptorCompanyusers, []int{22}
+}
+
+func (m *UndeleteCompanyRequest) GetID() int64 {
+	if m != nil {
+		return m.ID
+	}
+	return 0
+}
+
 type SaveCompanyUserRequest struct {
 	CompanyUser *CompanyUser `protobuf:"bytes,1,opt,name=company_user,json=companyUser" json:"company_user,omitempty"`
 }
@@ -403,7 +859,7 @@ func (m *SaveCompanyUserRequest) Reset()         { *m = SaveCompanyUserRequest{}
 func (m *SaveCompanyUserRequest) String() string { return proto.CompactTextString(m) }
 func (*SaveCompanyUserRequest) ProtoMessage()    {}
 func (*SaveCompanyUserRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorCompanyusers, []int{14}
+	return fileDescriptorCompanyusers, []int{23}
 }
 
 func (m *SaveCompanyUserRequest) GetCompanyUser() *CompanyUser {
@@ -421,7 +877,7 @@ func (m *FindCompanyUserRequest) Reset()         { *m = FindCompanyUserRequest{}
 func (m *FindCompanyUserRequest) String() string { return proto.CompactTextString(m) }
 func (*FindCompanyUserRequest) ProtoMessage()    {}
 func (*FindCompanyUserRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorCompanyusers, []int{15}
+	return fileDescriptorCompanyusers, []int{24}
 }
 
 func (m *FindCompanyUserRequest) GetID() int64 {
@@ -432,6 +888,12 @@ func (m *FindCompanyUserRequest) GetID() int64 {
 }
 
 type FindAllCompanyUsersRequest struct {
+	// filter is an AIP-160 expression over company_id, user_id, created_at and
+	// updated_at, e.g. company_id = 3 AND created_at > "2024-01-01"
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// order_by is a comma separated list of company_id, user_id, created_at
+	// and updated_at, each optionally followed by desc, e.g. "created_at desc"
+	OrderBy    string      `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
 	Pagination *Pagination `protobuf:"bytes,50,opt,name=pagination" json:"pagination,omitempty"`
 }
 
@@ -439,7 +901,21 @@ func (m *FindAllCompanyUsersRequest) Reset()         { *m = FindAllCompanyUsersR
 func (m *FindAllCompanyUsersRequest) String() string { return proto.CompactTextString(m) }
 func (*FindAllCompanyUsersRequest) ProtoMessage()    {}
 func (*FindAllCompanyUsersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorCompanyusers, []int{16}
+	return fileDescriptorCompanyusers, []int{25}
+}
+
+func (m *FindAllCompanyUsersRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *FindAllCompanyUsersRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
 }
 
 func (m *FindAllCompanyUsersRequest) GetPagination() *Pagination {
@@ -451,14 +927,20 @@ func (m *FindAllCompanyUsersRequest) GetPagination() *Pagination {
 
 type FindAllCompanyUsersResponse struct {
 	CompanyUsers []*CompanyUser `protobuf:"bytes,1,rep,name=company_users,json=companyUsers" json:"company_users,omitempty"`
-	Pagination   *Pagination    `protobuf:"bytes,50,opt,name=pagination" json:"pagination,omitempty"`
+	// pagination echoes the page_number and results_per_page of the page.
+	// Deprecated: use next_page_token.
+	Pagination *Pagination `protobuf:"bytes,50,opt,name=pagination" json:"pagination,omitempty"`
+	// next_page_token fetches the next page, empty on the last one
+	NextPageToken string `protobuf:"bytes,51,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_count is only set when include_total_count was asked for
+	TotalCount int64 `protobuf:"varint,52,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
 }
 
 func (m *FindAllCompanyUsersResponse) Reset()         { *m = FindAllCompanyUsersResponse{} }
 func (m *FindAllCompanyUsersResponse) String() string { return proto.CompactTextString(m) }
 func (*FindAllCompanyUsersResponse) ProtoMessage()    {}
 func (*FindAllCompanyUsersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorCompanyusers, []int{17}
+	return fileDescriptorCompanyusers, []int{26}
 }
 
 func (m *FindAllCompanyUsersResponse) GetCompanyUsers() []*CompanyUser {
@@ -475,15 +957,36 @@ func (m *FindAllCompanyUsersResponse) GetPagination() *Pagination {
 	return nil
 }
 
+func (m *FindAllCompanyUsersResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func (m *FindAllCompanyUsersResponse) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
 type FindAllUsersCompaniesRequest struct {
 	UserID int64 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// filter is an AIP-160 expression over company_id, created_at and
+	// updated_at of the memberships of the user, e.g. created_at > "2024-01-01"
+	Filter string `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	// order_by is a comma separated list of company_id, created_at and
+	// updated_at, each optionally followed by desc, e.g. "created_at desc"
+	OrderBy    string      `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	Pagination *Pagination `protobuf:"bytes,50,opt,name=pagination" json:"pagination,omitempty"`
 }
 
 func (m *FindAllUsersCompaniesRequest) Reset()         { *m = FindAllUsersCompaniesRequest{} }
 func (m *FindAllUsersCompaniesRequest) String() string { return proto.CompactTextString(m) }
 func (*FindAllUsersCompaniesRequest) ProtoMessage()    {}
 func (*FindAllUsersCompaniesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorCompanyusers, []int{18}
+	return fileDescriptorCompanyusers, []int{27}
 }
 
 func (m *FindAllUsersCompaniesRequest) GetUserID() int64 {
@@ -493,16 +996,43 @@ func (m *FindAllUsersCompaniesRequest) GetUserID() int64 {
 	return 0
 }
 
+func (m *FindAllUsersCompaniesRequest) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *FindAllUsersCompaniesRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+func (m *FindAllUsersCompaniesRequest) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
 type FindAllUsersCompaniesResponse struct {
-	CompanyIDs []int64     `protobuf:"varint,1,rep,packed,name=company_ids,json=companyIds" json:"company_ids,omitempty"`
+	CompanyIDs []int64 `protobuf:"varint,1,rep,packed,name=company_ids,json=companyIds" json:"company_ids,omitempty"`
+	// pagination echoes the page_number and results_per_page of the page.
+	// Deprecated: use next_page_token.
 	Pagination *Pagination `protobuf:"bytes,50,opt,name=pagination" json:"pagination,omitempty"`
+	// next_page_token fetches the next page, empty on the last one
+	NextPageToken string `protobuf:"bytes,51,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_count is only set when include_total_count was asked for
+	TotalCount int64 `protobuf:"varint,52,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
 }
 
 func (m *FindAllUsersCompaniesResponse) Reset()         { *m = FindAllUsersCompaniesResponse{} }
 func (m *FindAllUsersCompaniesResponse) String() string { return proto.CompactTextString(m) }
 func (*FindAllUsersCompaniesResponse) ProtoMessage()    {}
 func (*FindAllUsersCompaniesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptorCompanyusers, []int{19}
+	return fileDescriptorCompanyusers, []int{28}
 }
 
 func (m *FindAllUsersCompaniesResponse) GetCompanyIDs() []int64 {
@@ -519,6 +1049,20 @@ func (m *FindAllUsersCompaniesResponse) GetPagination() *Pagination {
 	return nil
 }
 
+func (m *FindAllUsersCompaniesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+func (m *FindAllUsersCompaniesResponse) GetTotalCount() int64 {
+	if m != nil {
+		return m.TotalCount
+	}
+	return 0
+}
+
 type DeleteCompanyUserRequest struct {
 	ID int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
@@ -527,7 +1071,7 @@ func (m *DeleteCompanyUserRequest) Reset()         { *m = DeleteCompanyUserReque
 func (m *DeleteCompanyUserRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteCompanyUserRequest) ProtoMessage()    {}
 func (*DeleteCompanyUserRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptorCompanyusers, []int{20}
+	return fileDescriptorCompanyusers, []int{29}
 }
 
 func (m *DeleteCompanyUserRequest) GetID() int64 {
@@ -542,16 +1086,25 @@ func init() {
 	proto.RegisterType((*Company)(nil), "companyusers.Company")
 	proto.RegisterType((*CompanyUser)(nil), "companyusers.CompanyUser")
 	proto.RegisterType((*Pagination)(nil), "companyusers.Pagination")
+	proto.RegisterType((*Highlight)(nil), "companyusers.Highlight")
 	proto.RegisterType((*SaveUserRequest)(nil), "companyusers.SaveUserRequest")
 	proto.RegisterType((*FindUserRequest)(nil), "companyusers.FindUserRequest")
 	proto.RegisterType((*FindAllUsersRequest)(nil), "companyusers.FindAllUsersRequest")
 	proto.RegisterType((*FindAllUsersResponse)(nil), "companyusers.FindAllUsersResponse")
 	proto.RegisterType((*DeleteUserRequest)(nil), "companyusers.DeleteUserRequest")
+	proto.RegisterType((*SearchUsersRequest)(nil), "companyusers.SearchUsersRequest")
+	proto.RegisterType((*UserSearchResult)(nil), "companyusers.UserSearchResult")
+	proto.RegisterType((*SearchUsersResponse)(nil), "companyusers.SearchUsersResponse")
+	proto.RegisterType((*UndeleteUserRequest)(nil), "companyusers.UndeleteUserRequest")
 	proto.RegisterType((*SaveCompanyRequest)(nil), "companyusers.SaveCompanyRequest")
 	proto.RegisterType((*FindCompanyRequest)(nil), "companyusers.FindCompanyRequest")
 	proto.RegisterType((*FindAllCompaniesRequest)(nil), "companyusers.FindAllCompaniesRequest")
 	proto.RegisterType((*FindAllCompaniesResponse)(nil), "companyusers.FindAllCompaniesResponse")
 	proto.RegisterType((*DeleteCompanyRequest)(nil), "companyusers.DeleteCompanyRequest")
+	proto.RegisterType((*SearchCompaniesRequest)(nil), "companyusers.SearchCompaniesRequest")
+	proto.RegisterType((*CompanySearchResult)(nil), "companyusers.CompanySearchResult")
+	proto.RegisterType((*SearchCompaniesResponse)(nil), "companyusers.SearchCompaniesResponse")
+	proto.RegisterType((*UndeleteCompanyRequest)(nil), "companyusers.UndeleteCompanyRequest")
 	proto.RegisterType((*SaveCompanyUserRequest)(nil), "companyusers.SaveCompanyUserRequest")
 	proto.RegisterType((*FindCompanyUserRequest)(nil), "companyusers.FindCompanyUserRequest")
 	proto.RegisterType((*FindAllCompanyUsersRequest)(nil), "companyusers.FindAllCompanyUsersRequest")
@@ -575,7 +1128,9 @@ type UserSvcClient interface {
 	Save(ctx context.Context, in *SaveUserRequest, opts ...grpc.CallOption) (*User, error)
 	Find(ctx context.Context, in *FindUserRequest, opts ...grpc.CallOption) (*User, error)
 	FindAll(ctx context.Context, in *FindAllUsersRequest, opts ...grpc.CallOption) (*FindAllUsersResponse, error)
-	Delete(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	Delete(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	Search(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error)
+	Undelete(ctx context.Context, in *UndeleteUserRequest, opts ...grpc.CallOption) (*User, error)
 }
 
 type userSvcClient struct {
@@ -613,8 +1168,8 @@ func (c *userSvcClient) FindAll(ctx context.Context, in *FindAllUsersRequest, op
 	return out, nil
 }
 
-func (c *userSvcClient) Delete(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
-	out := new(google_protobuf.Empty)
+func (c *userSvcClient) Delete(ctx context.Context, in *DeleteUserRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
 	err := grpc.Invoke(ctx, "/companyusers.UserSvc/Delete", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
@@ -622,13 +1177,33 @@ func (c *userSvcClient) Delete(ctx context.Context, in *DeleteUserRequest, opts
 	return out, nil
 }
 
+func (c *userSvcClient) Search(ctx context.Context, in *SearchUsersRequest, opts ...grpc.CallOption) (*SearchUsersResponse, error) {
+	out := new(SearchUsersResponse)
+	err := grpc.Invoke(ctx, "/companyusers.UserSvc/Search", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userSvcClient) Undelete(ctx context.Context, in *UndeleteUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := grpc.Invoke(ctx, "/companyusers.UserSvc/Undelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for UserSvc service
 
 type UserSvcServer interface {
 	Save(context.Context, *SaveUserRequest) (*User, error)
 	Find(context.Context, *FindUserRequest) (*User, error)
 	FindAll(context.Context, *FindAllUsersRequest) (*FindAllUsersResponse, error)
-	Delete(context.Context, *DeleteUserRequest) (*google_protobuf.Empty, error)
+	Delete(context.Context, *DeleteUserRequest) (*google_protobuf1.Empty, error)
+	Search(context.Context, *SearchUsersRequest) (*SearchUsersResponse, error)
+	Undelete(context.Context, *UndeleteUserRequest) (*User, error)
 }
 
 func RegisterUserSvcServer(s *grpc.Server, srv UserSvcServer) {
@@ -707,6 +1282,42 @@ func _UserSvc_Delete_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserSvc_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserSvcServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/companyusers.UserSvc/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserSvcServer).Search(ctx, req.(*SearchUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserSvc_Undelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserSvcServer).Undelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/companyusers.UserSvc/Undelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserSvcServer).Undelete(ctx, req.(*UndeleteUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _UserSvc_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "companyusers.UserSvc",
 	HandlerType: (*UserSvcServer)(nil),
@@ -727,6 +1338,14 @@ var _UserSvc_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _UserSvc_Delete_Handler,
 		},
+		{
+			MethodName: "Search",
+			Handler:    _UserSvc_Search_Handler,
+		},
+		{
+			MethodName: "Undelete",
+			Handler:    _UserSvc_Undelete_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "companyusers/companyusers.proto",
@@ -738,7 +1357,9 @@ type CompanySvcClient interface {
 	Save(ctx context.Context, in *SaveCompanyRequest, opts ...grpc.CallOption) (*Company, error)
 	Find(ctx context.Context, in *FindCompanyRequest, opts ...grpc.CallOption) (*Company, error)
 	FindAll(ctx context.Context, in *FindAllCompaniesRequest, opts ...grpc.CallOption) (*FindAllCompaniesResponse, error)
-	Delete(ctx context.Context, in *DeleteCompanyRequest, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	Delete(ctx context.Context, in *DeleteCompanyRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
+	Search(ctx context.Context, in *SearchCompaniesRequest, opts ...grpc.CallOption) (*SearchCompaniesResponse, error)
+	Undelete(ctx context.Context, in *UndeleteCompanyRequest, opts ...grpc.CallOption) (*Company, error)
 }
 
 type companySvcClient struct {
@@ -776,8 +1397,8 @@ func (c *companySvcClient) FindAll(ctx context.Context, in *FindAllCompaniesRequ
 	return out, nil
 }
 
-func (c *companySvcClient) Delete(ctx context.Context, in *DeleteCompanyRequest, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
-	out := new(google_protobuf.Empty)
+func (c *companySvcClient) Delete(ctx context.Context, in *DeleteCompanyRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
 	err := grpc.Invoke(ctx, "/companyusers.CompanySvc/Delete", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
@@ -785,13 +1406,33 @@ func (c *companySvcClient) Delete(ctx context.Context, in *DeleteCompanyRequest,
 	return out, nil
 }
 
+func (c *companySvcClient) Search(ctx context.Context, in *SearchCompaniesRequest, opts ...grpc.CallOption) (*SearchCompaniesResponse, error) {
+	out := new(SearchCompaniesResponse)
+	err := grpc.Invoke(ctx, "/companyusers.CompanySvc/Search", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *companySvcClient) Undelete(ctx context.Context, in *UndeleteCompanyRequest, opts ...grpc.CallOption) (*Company, error) {
+	out := new(Company)
+	err := grpc.Invoke(ctx, "/companyusers.CompanySvc/Undelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for CompanySvc service
 
 type CompanySvcServer interface {
 	Save(context.Context, *SaveCompanyRequest) (*Company, error)
 	Find(context.Context, *FindCompanyRequest) (*Company, error)
 	FindAll(context.Context, *FindAllCompaniesRequest) (*FindAllCompaniesResponse, error)
-	Delete(context.Context, *DeleteCompanyRequest) (*google_protobuf.Empty, error)
+	Delete(context.Context, *DeleteCompanyRequest) (*google_protobuf1.Empty, error)
+	Search(context.Context, *SearchCompaniesRequest) (*SearchCompaniesResponse, error)
+	Undelete(context.Context, *UndeleteCompanyRequest) (*Company, error)
 }
 
 func RegisterCompanySvcServer(s *grpc.Server, srv CompanySvcServer) {
@@ -870,6 +1511,42 @@ func _CompanySvc_Delete_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CompanySvc_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchCompaniesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompanySvcServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/companyusers.CompanySvc/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompanySvcServer).Search(ctx, req.(*SearchCompaniesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CompanySvc_Undelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteCompanyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CompanySvcServer).Undelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/companyusers.CompanySvc/Undelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CompanySvcServer).Undelete(ctx, req.(*UndeleteCompanyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _CompanySvc_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "companyusers.CompanySvc",
 	HandlerType: (*CompanySvcServer)(nil),
@@ -890,6 +1567,14 @@ var _CompanySvc_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _CompanySvc_Delete_Handler,
 		},
+		{
+			MethodName: "Search",
+			Handler:    _CompanySvc_Search_Handler,
+		},
+		{
+			MethodName: "Undelete",
+			Handler:    _CompanySvc_Undelete_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "companyusers/companyusers.proto",
@@ -902,7 +1587,7 @@ type CompanyUserSvcClient interface {
 	Find(ctx context.Context, in *FindCompanyUserRequest, opts ...grpc.CallOption) (*CompanyUser, error)
 	FindAllCompanyUsers(ctx context.Context, in *FindAllCompanyUsersRequest, opts ...grpc.CallOption) (*FindAllCompanyUsersResponse, error)
 	FindAllUsersCompanies(ctx context.Context, in *FindAllUsersCompaniesRequest, opts ...grpc.CallOption) (*FindAllUsersCompaniesResponse, error)
-	Delete(ctx context.Context, in *DeleteCompanyUserRequest, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	Delete(ctx context.Context, in *DeleteCompanyUserRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error)
 }
 
 type companyUserSvcClient struct {
@@ -949,8 +1634,8 @@ func (c *companyUserSvcClient) FindAllUsersCompanies(ctx context.Context, in *Fi
 	return out, nil
 }
 
-func (c *companyUserSvcClient) Delete(ctx context.Context, in *DeleteCompanyUserRequest, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
-	out := new(google_protobuf.Empty)
+func (c *companyUserSvcClient) Delete(ctx context.Context, in *DeleteCompanyUserRequest, opts ...grpc.CallOption) (*google_protobuf1.Empty, error) {
+	out := new(google_protobuf1.Empty)
 	err := grpc.Invoke(ctx, "/companyusers.CompanyUserSvc/Delete", in, out, c.cc, opts...)
 	if err != nil {
 		return nil, err
@@ -965,7 +1650,7 @@ type CompanyUserSvcServer interface {
 	Find(context.Context, *FindCompanyUserRequest) (*CompanyUser, error)
 	FindAllCompanyUsers(context.Context, *FindAllCompanyUsersRequest) (*FindAllCompanyUsersResponse, error)
 	FindAllUsersCompanies(context.Context, *FindAllUsersCompaniesRequest) (*FindAllUsersCompaniesResponse, error)
-	Delete(context.Context, *DeleteCompanyUserRequest) (*google_protobuf.Empty, error)
+	Delete(context.Context, *DeleteCompanyUserRequest) (*google_protobuf1.Empty, error)
 }
 
 func RegisterCompanyUserSvcServer(s *grpc.Server, srv CompanyUserSvcServer) {
@@ -1094,63 +1779,110 @@ var _CompanyUserSvc_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("companyusers/companyusers.proto", fileDescriptorCompanyusers) }
 
 var fileDescriptorCompanyusers = []byte{
-	// 915 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x56, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0x8e, 0xf3, 0x4b, 0x4e, 0xda, 0xb4, 0x0c, 0xdb, 0x60, 0xbc, 0xac, 0x1c, 0x86, 0xb2, 0x4a,
-	0x69, 0x49, 0x50, 0xf6, 0x86, 0x0a, 0x84, 0x94, 0xdd, 0xb4, 0x52, 0xb8, 0x28, 0x2b, 0x2f, 0x14,
-	0xa9, 0x37, 0x91, 0x93, 0x4c, 0x8d, 0x25, 0x3b, 0x36, 0xb6, 0x53, 0x69, 0x79, 0x03, 0x78, 0x01,
-	0x6e, 0x78, 0x03, 0xee, 0x79, 0x12, 0xc4, 0x65, 0x2e, 0x72, 0xc1, 0x73, 0xa0, 0x19, 0x8f, 0x9d,
-	0xb1, 0x1d, 0x67, 0x2d, 0x65, 0xa5, 0xbd, 0xcb, 0xcc, 0xf9, 0xce, 0xcf, 0xf7, 0x9d, 0x33, 0x27,
-	0x06, 0x75, 0xee, 0xd8, 0xae, 0xbe, 0xbc, 0x5e, 0xf9, 0xc4, 0xf3, 0x07, 0xe2, 0xa1, 0xef, 0x7a,
-	0x4e, 0xe0, 0xa0, 0x7b, 0xe2, 0x9d, 0x72, 0x6c, 0x38, 0x8e, 0x61, 0x91, 0x01, 0xb3, 0xcd, 0x56,
-	0x6f, 0x07, 0xc4, 0x76, 0x83, 0xeb, 0x10, 0xaa, 0xa8, 0x69, 0x63, 0x60, 0xda, 0xc4, 0x0f, 0x74,
-	0xdb, 0xe5, 0x80, 0x2f, 0x0c, 0x33, 0xf8, 0x79, 0x35, 0xeb, 0xcf, 0x1d, 0x7b, 0x60, 0x38, 0x86,
-	0xb3, 0x45, 0xd2, 0x13, 0x3b, 0xb0, 0x5f, 0x21, 0x1c, 0xff, 0x27, 0x41, 0xf5, 0x47, 0x9f, 0x78,
-	0xa8, 0x03, 0x65, 0x73, 0x21, 0x4b, 0x5d, 0xa9, 0x57, 0x39, 0xaf, 0x6f, 0xd6, 0x6a, 0x79, 0x32,
-	0xd6, 0xca, 0xe6, 0x02, 0x9d, 0x00, 0xbc, 0x35, 0x3d, 0x3f, 0x98, 0x2e, 0x75, 0x9b, 0xc8, 0xe5,
-	0xae, 0xd4, 0x6b, 0x6a, 0x4d, 0x76, 0xf3, 0x4a, 0xb7, 0x09, 0x3a, 0x86, 0xa6, 0xa5, 0x47, 0xd6,
-	0x0a, 0xb3, 0xbe, 0x47, 0x2f, 0x98, 0xf1, 0x08, 0x6a, 0xc4, 0xd6, 0x4d, 0x4b, 0xae, 0x32, 0x43,
-	0x78, 0x40, 0xcf, 0x01, 0xe6, 0x1e, 0xd1, 0x03, 0xb2, 0x98, 0xea, 0x81, 0x3c, 0xec, 0x4a, 0xbd,
-	0xd6, 0x50, 0xe9, 0x87, 0xbc, 0xfa, 0x51, 0xb5, 0xfd, 0x1f, 0x22, 0x5e, 0x5a, 0x93, 0xa3, 0x47,
-	0x01, 0x75, 0x5d, 0xb9, 0x8b, 0xc8, 0xf5, 0xec, 0x66, 0x57, 0x8e, 0x1e, 0x05, 0xf8, 0x2f, 0x09,
-	0x1a, 0x17, 0xa1, 0xcc, 0xb9, 0x5c, 0x11, 0x54, 0x05, 0x96, 0xec, 0xf7, 0x1d, 0x55, 0xfb, 0xaf,
-	0x04, 0x2d, 0x5e, 0x2d, 0xeb, 0xce, 0x33, 0x00, 0x3e, 0x23, 0xd3, 0xb8, 0xf2, 0xfb, 0x9b, 0xb5,
-	0xda, 0xe4, 0xa0, 0xc9, 0x58, 0x6b, 0x72, 0xc0, 0x64, 0x81, 0x3e, 0x85, 0x06, 0x1d, 0x25, 0x0a,
-	0x2d, 0x33, 0x28, 0x6c, 0xd6, 0x6a, 0x9d, 0x06, 0x9a, 0x8c, 0xb5, 0x3a, 0x35, 0x4d, 0x16, 0x77,
-	0x44, 0xec, 0x27, 0x80, 0x4b, 0xdd, 0x30, 0x97, 0x7a, 0x60, 0x3a, 0x4b, 0xa4, 0x42, 0xcb, 0xd5,
-	0x0d, 0x32, 0x5d, 0xae, 0xec, 0x19, 0xf1, 0x18, 0xaf, 0x9a, 0x06, 0xf4, 0xea, 0x15, 0xbb, 0x41,
-	0x3d, 0x78, 0xe8, 0x11, 0x7f, 0x65, 0x05, 0xfe, 0xd4, 0x25, 0xde, 0x94, 0x5a, 0x18, 0xa5, 0x9a,
-	0xd6, 0xe6, 0xf7, 0x97, 0xc4, 0xbb, 0xd4, 0x0d, 0x82, 0x9f, 0xc3, 0x83, 0x2b, 0xfd, 0x1d, 0xa1,
-	0x24, 0x35, 0xf2, 0xcb, 0x8a, 0xf8, 0x01, 0x3a, 0x85, 0x2a, 0xe5, 0xca, 0xc2, 0xb6, 0x86, 0xa8,
-	0x9f, 0x78, 0x79, 0x0c, 0xc8, 0xec, 0xf8, 0x09, 0x3c, 0x78, 0x69, 0x2e, 0x17, 0xa2, 0x6b, 0xce,
-	0x84, 0xe0, 0xef, 0xe1, 0x03, 0x0a, 0x1d, 0x59, 0x16, 0x45, 0xfb, 0x11, 0xfc, 0x2b, 0xa0, 0x45,
-	0x73, 0x56, 0x5c, 0x4b, 0x39, 0x99, 0x6f, 0xcb, 0x5a, 0x13, 0xb0, 0xf8, 0x57, 0x38, 0x4a, 0x06,
-	0xf4, 0x5d, 0x67, 0xe9, 0x13, 0xd4, 0x83, 0x1a, 0xf3, 0x93, 0xa5, 0x6e, 0x25, 0xa7, 0xf8, 0x10,
-	0x70, 0x40, 0xee, 0xa7, 0xf0, 0xfe, 0x98, 0x58, 0x24, 0x20, 0x45, 0x98, 0xbf, 0x00, 0x44, 0xf5,
-	0xe5, 0xf3, 0x16, 0xa1, 0x07, 0xd0, 0xe0, 0x99, 0xb8, 0xca, 0x8f, 0x92, 0x99, 0x23, 0x78, 0x84,
-	0xc2, 0xcf, 0x00, 0x51, 0xbe, 0xa9, 0x30, 0x79, 0x49, 0xaf, 0xe0, 0x43, 0xae, 0x4e, 0xe8, 0x60,
-	0x92, 0x5b, 0x90, 0xfc, 0x37, 0x09, 0xe4, 0x6c, 0x54, 0xae, 0xfb, 0x19, 0xf0, 0x77, 0x64, 0x92,
-	0x48, 0xfb, 0x1c, 0x4a, 0x5b, 0xdc, 0x01, 0xb5, 0xf4, 0xe1, 0x28, 0x6c, 0x41, 0x41, 0x41, 0x5e,
-	0x43, 0x47, 0xe8, 0x82, 0xd8, 0xb7, 0x6f, 0x20, 0xfa, 0x17, 0x99, 0x0a, 0x43, 0xff, 0xd1, 0xce,
-	0xda, 0x99, 0x5f, 0x6b, 0xbe, 0x3d, 0xe0, 0x2f, 0xa1, 0x23, 0xb4, 0xa5, 0xc8, 0x3c, 0xbc, 0x06,
-	0x25, 0x21, 0xe2, 0xf5, 0x2d, 0x3d, 0x88, 0x3f, 0x24, 0x38, 0xde, 0x19, 0x98, 0x37, 0xe8, 0x5b,
-	0xb8, 0x2f, 0xf2, 0x8c, 0x9a, 0xb4, 0x87, 0xe8, 0x3d, 0x81, 0xe8, 0x21, 0xbd, 0xba, 0x80, 0x8f,
-	0xc5, 0xa7, 0x9a, 0x99, 0x48, 0x61, 0xeb, 0x4a, 0x79, 0x5b, 0x17, 0xff, 0x2e, 0xc1, 0x49, 0x4e,
-	0x14, 0x4e, 0x70, 0x00, 0xad, 0xed, 0xaa, 0x0f, 0xe9, 0x55, 0xce, 0xdb, 0x9b, 0xb5, 0x0a, 0xf1,
-	0xae, 0xf7, 0x35, 0x88, 0x97, 0xfd, 0x21, 0x8c, 0x86, 0x20, 0x27, 0xa6, 0xaf, 0x40, 0xdf, 0x87,
-	0x7f, 0x96, 0xa1, 0x41, 0x71, 0x57, 0xef, 0xe6, 0xe8, 0x6b, 0xa8, 0xd2, 0x69, 0x44, 0x27, 0xc9,
-	0x6c, 0xa9, 0x3d, 0xac, 0xec, 0x58, 0x5e, 0xb8, 0x44, 0x9d, 0xa9, 0x10, 0x69, 0xe7, 0xd4, 0x26,
-	0xce, 0x71, 0xd6, 0xa0, 0xc1, 0x55, 0x44, 0x9f, 0x64, 0xfd, 0x53, 0xeb, 0x59, 0xc1, 0xfb, 0x20,
-	0xa1, 0xec, 0xb8, 0x84, 0x2e, 0xa0, 0x1e, 0xaa, 0x81, 0xd4, 0x24, 0x3e, 0xb3, 0x24, 0x95, 0x4e,
-	0xe6, 0xcf, 0xee, 0x05, 0xfd, 0x46, 0xc3, 0xa5, 0xe1, 0xdf, 0x65, 0x88, 0xfa, 0x44, 0x15, 0x1a,
-	0x71, 0x85, 0xba, 0x59, 0x85, 0x92, 0x2f, 0x5e, 0xd9, 0xbd, 0x65, 0x70, 0x89, 0x86, 0x60, 0x3a,
-	0x75, 0xb3, 0x24, 0x8a, 0x86, 0x78, 0xb3, 0x55, 0xeb, 0xb3, 0x9d, 0x52, 0xa4, 0x67, 0x59, 0x39,
-	0xbd, 0x09, 0x16, 0xab, 0xf6, 0x32, 0x56, 0x0d, 0xef, 0x52, 0x2d, 0x55, 0x62, 0xbe, 0x70, 0xff,
-	0x54, 0xa0, 0x2d, 0x8c, 0x21, 0x15, 0x6f, 0xc2, 0xc5, 0x7b, 0x9c, 0x2b, 0x9e, 0xd8, 0x93, 0xfc,
-	0x0d, 0x80, 0x4b, 0x34, 0x14, 0x13, 0xf1, 0x71, 0xae, 0x88, 0x85, 0x43, 0x59, 0xf1, 0x27, 0x80,
-	0xb8, 0x9f, 0x50, 0x6f, 0x8f, 0x62, 0x89, 0xdd, 0xa8, 0x3c, 0x29, 0x80, 0x8c, 0xe5, 0xf5, 0xe0,
-	0xd1, 0xce, 0x75, 0x81, 0x3e, 0xcf, 0x9f, 0xe9, 0x4c, 0x37, 0x9f, 0x16, 0xc2, 0xc6, 0x39, 0xbf,
-	0x8b, 0x5b, 0x7a, 0xba, 0xa7, 0xa5, 0x85, 0xde, 0xc3, 0xf9, 0xc3, 0x37, 0x6d, 0x31, 0x84, 0x3b,
-	0x9b, 0xd5, 0x19, 0xe6, 0xec, 0xff, 0x00, 0x00, 0x00, 0xff, 0xff, 0xe5, 0x18, 0x7b, 0x59, 0x16,
-	0x0d, 0x00, 0x00,
+	// 1669 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x58, 0x4b, 0x6f, 0x1b, 0x47,
+	0x12, 0x46, 0xf3, 0xcd, 0xa2, 0x5e, 0x6e, 0xbd, 0xc6, 0x94, 0x64, 0x52, 0x63, 0x59, 0x90, 0xe5,
+	0x35, 0xb9, 0x90, 0x76, 0xd7, 0x5e, 0x7b, 0xb1, 0x80, 0x68, 0xad, 0xb1, 0x02, 0xd6, 0xb6, 0xd0,
+	0xb6, 0x05, 0x6c, 0x02, 0x84, 0x18, 0x91, 0x2d, 0x6a, 0x6c, 0x72, 0x86, 0xe6, 0x0c, 0x9d, 0xc8,
+	0x81, 0x03, 0x24, 0xc8, 0x25, 0xb9, 0x1a, 0x39, 0xe5, 0x92, 0xb3, 0xfe, 0x40, 0x0e, 0xba, 0xe5,
+	0x92, 0x00, 0x39, 0x06, 0x48, 0x4e, 0x71, 0x00, 0xc1, 0x87, 0xfc, 0x8c, 0xa0, 0x7b, 0x6a, 0xc8,
+	0x79, 0x51, 0xa2, 0x63, 0x03, 0x76, 0x72, 0x92, 0xba, 0xfb, 0xeb, 0xaa, 0xea, 0xfa, 0xbe, 0xaa,
+	0xee, 0x21, 0x14, 0x6a, 0x66, 0xab, 0xad, 0x19, 0x07, 0x5d, 0x8b, 0x77, 0xac, 0xb2, 0x77, 0x50,
+	0x6a, 0x77, 0x4c, 0xdb, 0xa4, 0x23, 0xde, 0xb9, 0xfc, 0x7c, 0xc3, 0x34, 0x1b, 0x4d, 0x5e, 0xd6,
+	0xda, 0x7a, 0x59, 0x33, 0x0c, 0xd3, 0xd6, 0x6c, 0xdd, 0x34, 0x10, 0x9b, 0x9f, 0xc3, 0x55, 0x39,
+	0xda, 0xed, 0xee, 0x95, 0x79, 0xab, 0x6d, 0x1f, 0xe0, 0x62, 0x31, 0xb8, 0xb8, 0xa7, 0xf3, 0x66,
+	0xbd, 0xda, 0xd2, 0xac, 0x87, 0x88, 0x28, 0x04, 0x11, 0xb6, 0xde, 0xe2, 0x96, 0xad, 0xb5, 0xda,
+	0x08, 0xb8, 0xdc, 0xd0, 0xed, 0xfd, 0xee, 0x6e, 0xa9, 0x66, 0xb6, 0xca, 0x0d, 0xb3, 0x61, 0xf6,
+	0x91, 0x62, 0x24, 0x07, 0xf2, 0x3f, 0x84, 0x4f, 0x9b, 0x6d, 0x19, 0x5d, 0x19, 0xff, 0x3a, 0xd3,
+	0xea, 0x2f, 0x31, 0x48, 0xdc, 0xb7, 0x78, 0x87, 0xce, 0x40, 0x4c, 0xaf, 0x2b, 0xa4, 0x48, 0x56,
+	0xe2, 0x95, 0xd4, 0xf1, 0xf3, 0x42, 0x6c, 0x6b, 0x93, 0xc5, 0xf4, 0x3a, 0xbd, 0x04, 0xb0, 0xa7,
+	0x77, 0x2c, 0xbb, 0x6a, 0x68, 0x2d, 0xae, 0xc4, 0x8a, 0x64, 0x25, 0x5b, 0x19, 0xf9, 0xea, 0x48,
+	0x21, 0x87, 0x47, 0x4a, 0x22, 0x43, 0x94, 0x3a, 0xcb, 0xca, 0xf5, 0xdb, 0x5a, 0x8b, 0xd3, 0x8b,
+	0x90, 0x6d, 0x6a, 0x2e, 0x36, 0x1e, 0x81, 0xcd, 0x34, 0x35, 0x84, 0xaa, 0x90, 0xe4, 0x2d, 0x4d,
+	0x6f, 0x2a, 0x89, 0x20, 0x6c, 0x85, 0x30, 0x67, 0x89, 0xfe, 0x13, 0xa0, 0xd6, 0xe1, 0x9a, 0xcd,
+	0xeb, 0x55, 0xcd, 0x56, 0xd6, 0x8a, 0x64, 0x25, 0xb7, 0x96, 0x2f, 0x39, 0x89, 0x29, 0xb9, 0xc7,
+	0x2d, 0xdd, 0x73, 0x13, 0xc3, 0xb2, 0x88, 0xde, 0xb0, 0xc5, 0xd6, 0x6e, 0xbb, 0xee, 0x6e, 0x5d,
+	0x3f, 0x7d, 0x2b, 0xa2, 0x37, 0x6c, 0x4a, 0x21, 0xc1, 0x6d, 0xad, 0xa1, 0xfc, 0x4d, 0x04, 0xc6,
+	0xe4, 0xff, 0xc2, 0x5c, 0x9d, 0x37, 0x39, 0x9a, 0xfb, 0xfb, 0xe9, 0xe6, 0x10, 0xbd, 0x61, 0xab,
+	0x9f, 0xc6, 0x20, 0x7d, 0xc3, 0x91, 0xcd, 0xc0, 0x24, 0xcf, 0x43, 0xc2, 0x93, 0xde, 0x0c, 0xa6,
+	0x6b, 0x9b, 0xc9, 0xd9, 0x3f, 0x47, 0x1a, 0x8e, 0x09, 0xe4, 0x30, 0x0d, 0x52, 0x6f, 0x6b, 0x00,
+	0x58, 0x4c, 0xd5, 0x5e, 0x4a, 0x26, 0x0f, 0x8f, 0x94, 0x58, 0x86, 0x1c, 0x3f, 0x2f, 0x64, 0x11,
+	0xba, 0xb5, 0xc9, 0xb2, 0x08, 0xdb, 0xaa, 0xd3, 0x8b, 0x90, 0x16, 0x95, 0x27, 0x36, 0xc4, 0xe4,
+	0x86, 0x89, 0xde, 0x86, 0x94, 0x30, 0xba, 0xb5, 0xc9, 0x52, 0x02, 0xb0, 0x55, 0x7f, 0x33, 0x39,
+	0x53, 0xbf, 0x27, 0x00, 0xdb, 0x5a, 0x43, 0x37, 0x64, 0x27, 0xa0, 0xe7, 0x21, 0xd7, 0xd6, 0x1a,
+	0xbc, 0x6a, 0x74, 0x5b, 0xbb, 0xbc, 0x23, 0x0f, 0x99, 0xac, 0xc4, 0x14, 0xc2, 0x40, 0x4c, 0xdf,
+	0x96, 0xb3, 0xf4, 0x2f, 0x30, 0xd1, 0xe1, 0x56, 0xb7, 0x69, 0x5b, 0xd5, 0x36, 0xef, 0x54, 0xc5,
+	0x8a, 0x12, 0xeb, 0x21, 0xc7, 0x70, 0x6d, 0x9b, 0x77, 0xb6, 0xb5, 0x06, 0xa7, 0x73, 0x90, 0x95,
+	0x26, 0x2d, 0xfd, 0x89, 0x53, 0x61, 0x49, 0x96, 0x11, 0x13, 0x77, 0xf5, 0x27, 0x9c, 0x2e, 0x80,
+	0x34, 0x5c, 0xb5, 0xcd, 0x87, 0xdc, 0x70, 0x0a, 0x8b, 0x49, 0xf8, 0x3d, 0x31, 0x41, 0x4b, 0x30,
+	0xa9, 0x1b, 0xb5, 0x66, 0xb7, 0x2e, 0x10, 0xb6, 0xd6, 0xac, 0xd6, 0xcc, 0xae, 0x61, 0x2b, 0xc9,
+	0x22, 0x59, 0xc9, 0xb0, 0x33, 0xb8, 0x74, 0x4f, 0xac, 0xdc, 0x10, 0x0b, 0xea, 0x75, 0xc8, 0xfe,
+	0x57, 0x6f, 0xec, 0x37, 0xf5, 0xc6, 0xbe, 0x4d, 0xa7, 0x20, 0x29, 0x7b, 0x94, 0x3c, 0x45, 0x96,
+	0x39, 0x03, 0xaa, 0x40, 0xda, 0x32, 0xf4, 0x76, 0x9b, 0xdb, 0x8e, 0x76, 0x99, 0x3b, 0x54, 0x3f,
+	0x82, 0xf1, 0xbb, 0xda, 0x63, 0x2e, 0x68, 0x61, 0xfc, 0x51, 0x97, 0x5b, 0x36, 0x2d, 0x41, 0x42,
+	0xb0, 0x23, 0x2d, 0xe4, 0xd6, 0x68, 0xc9, 0xd7, 0x60, 0x05, 0xb0, 0x92, 0x72, 0xf8, 0x64, 0x12,
+	0x47, 0xaf, 0x43, 0xce, 0x49, 0xad, 0xec, 0x8b, 0x4a, 0x6c, 0x00, 0x13, 0x37, 0x45, 0x24, 0xb7,
+	0x34, 0xeb, 0x21, 0x43, 0xde, 0xc4, 0xff, 0xea, 0xff, 0x60, 0xfc, 0xa6, 0x6e, 0xd4, 0xbd, 0xfe,
+	0x07, 0x55, 0xdf, 0x22, 0x8c, 0x58, 0xfb, 0xe6, 0xfb, 0x55, 0x14, 0xab, 0x74, 0x94, 0x61, 0x39,
+	0x31, 0xb7, 0xe9, 0x4c, 0xa9, 0x5f, 0x13, 0x98, 0x14, 0xe6, 0x36, 0x9a, 0x4d, 0x61, 0xd1, 0x72,
+	0x4d, 0x16, 0x20, 0xb5, 0xa7, 0x37, 0x6d, 0xde, 0xc1, 0xd2, 0x4d, 0x1f, 0x1e, 0x29, 0x71, 0xe5,
+	0xd7, 0x34, 0xc3, 0x69, 0xaa, 0x42, 0xc6, 0xec, 0xd4, 0x79, 0xa7, 0xba, 0x7b, 0xa0, 0xc4, 0x3d,
+	0x90, 0xef, 0x08, 0x4b, 0xcb, 0x85, 0xca, 0x41, 0xc8, 0x7f, 0x22, 0xe4, 0x9f, 0x5e, 0x95, 0xcc,
+	0xa2, 0xae, 0x50, 0xce, 0x8a, 0x3f, 0x81, 0x7d, 0xdd, 0x31, 0x0f, 0x56, 0xfd, 0x96, 0xc0, 0x94,
+	0x3f, 0x72, 0xab, 0x6d, 0x1a, 0x16, 0xa7, 0x2b, 0x90, 0x94, 0x1b, 0x15, 0x52, 0x8c, 0x47, 0xd3,
+	0xc1, 0x1c, 0x00, 0xfd, 0xd7, 0xcb, 0x38, 0xef, 0xe9, 0x1b, 0xc7, 0x74, 0x19, 0xc6, 0x0d, 0xfe,
+	0x81, 0x5d, 0xf5, 0x28, 0x73, 0x5d, 0x4a, 0x65, 0x54, 0x4c, 0x6f, 0xf7, 0xd4, 0x59, 0x80, 0x9c,
+	0x57, 0x95, 0xa2, 0xed, 0xc4, 0x19, 0xd8, 0x7d, 0x39, 0x5e, 0x82, 0x33, 0x4e, 0x3a, 0x86, 0xe0,
+	0x54, 0xdd, 0x01, 0x7a, 0x97, 0x6b, 0x9d, 0xda, 0xbe, 0x8f, 0xae, 0xf3, 0x90, 0x7c, 0xd4, 0xe5,
+	0x9d, 0x03, 0x47, 0xc4, 0x95, 0x51, 0xbc, 0x74, 0x92, 0x19, 0x22, 0x08, 0x71, 0xd6, 0xfc, 0x25,
+	0x16, 0xf3, 0x97, 0x98, 0xfa, 0x19, 0x81, 0x09, 0x61, 0xd2, 0x31, 0xce, 0x64, 0x71, 0xd2, 0xe5,
+	0xd3, 0x84, 0x8d, 0x82, 0x9e, 0x82, 0xa4, 0x55, 0x33, 0x3b, 0x8e, 0x55, 0xc2, 0x9c, 0x01, 0xbd,
+	0x02, 0xb0, 0xef, 0x96, 0x99, 0xa5, 0xc4, 0x25, 0x1b, 0xb3, 0x7e, 0x1b, 0xbd, 0x32, 0x64, 0x1e,
+	0xa8, 0x7a, 0x07, 0x26, 0x7d, 0x67, 0x44, 0x62, 0xaf, 0x42, 0x1a, 0x9b, 0x06, 0x52, 0x7b, 0x2e,
+	0x1c, 0x90, 0x37, 0x7c, 0xe6, 0xc2, 0xd5, 0x75, 0x98, 0xbc, 0x6f, 0xd4, 0x43, 0x39, 0x9e, 0xf7,
+	0xe4, 0x78, 0xa4, 0xd7, 0x71, 0xdd, 0x4c, 0x7f, 0x4e, 0x80, 0x8a, 0x4a, 0xc7, 0x8e, 0xed, 0x6e,
+	0xba, 0x02, 0x69, 0xf4, 0x8a, 0x69, 0x99, 0xf6, 0x47, 0x81, 0xf0, 0x5e, 0xc9, 0xbb, 0xe8, 0x57,
+	0xab, 0xfa, 0x3b, 0x40, 0x85, 0xd8, 0x03, 0xb1, 0xbc, 0x42, 0xe1, 0x1f, 0x11, 0x98, 0xc5, 0xf2,
+	0x71, 0x8c, 0xea, 0xfc, 0x0f, 0x54, 0xfc, 0x3f, 0x12, 0x50, 0xc2, 0xd1, 0xa3, 0x4e, 0xd6, 0x01,
+	0xaf, 0x56, 0x9d, 0xbb, 0x4a, 0x89, 0xe6, 0x88, 0xf5, 0x71, 0x6f, 0x4b, 0x2f, 0x28, 0xc1, 0x94,
+	0x93, 0x9d, 0xe1, 0x98, 0x56, 0x77, 0x60, 0xc6, 0x91, 0x7c, 0x04, 0x89, 0xbe, 0x96, 0x90, 0x7d,
+	0xb9, 0x76, 0xf0, 0x05, 0x81, 0x49, 0x0c, 0xc1, 0xd7, 0x11, 0xca, 0xc3, 0xa9, 0xbf, 0xaf, 0xfa,
+	0xd7, 0xdc, 0x1a, 0x76, 0x60, 0x36, 0x74, 0x5e, 0xa4, 0xfd, 0x7a, 0xb0, 0x3d, 0x2c, 0x46, 0x86,
+	0x16, 0xdd, 0x21, 0xfe, 0x01, 0x33, 0x6e, 0x87, 0x08, 0x64, 0xfe, 0xe4, 0x26, 0xf1, 0x1e, 0xcc,
+	0x78, 0x7a, 0x84, 0xb7, 0xb9, 0x6c, 0x82, 0xfb, 0x51, 0x55, 0xf5, 0xf4, 0xd0, 0xb3, 0x91, 0x31,
+	0xf9, 0xde, 0x08, 0xb9, 0x5a, 0x7f, 0x52, 0xfd, 0x2b, 0xcc, 0x78, 0xea, 0x7e, 0x98, 0x0b, 0xe2,
+	0x4b, 0x02, 0x79, 0x5f, 0x69, 0x1c, 0xbc, 0xfe, 0x8b, 0xfd, 0xf7, 0x17, 0xee, 0x0b, 0x02, 0x73,
+	0x91, 0xd1, 0x21, 0x89, 0xff, 0x86, 0x51, 0x6f, 0xd6, 0x5c, 0x2a, 0x07, 0xa7, 0x8d, 0x8d, 0x78,
+	0xd2, 0xf5, 0xd6, 0x94, 0xf1, 0x37, 0x04, 0xe6, 0xbd, 0x8f, 0x93, 0x50, 0x75, 0x9e, 0xef, 0xbf,
+	0xf8, 0x1d, 0x0a, 0x21, 0xe2, 0xad, 0xff, 0x86, 0xb9, 0xfa, 0x89, 0xc0, 0xc2, 0x80, 0x43, 0x20,
+	0x5b, 0x65, 0xc8, 0xf5, 0xbf, 0x75, 0x1c, 0xae, 0xe2, 0x95, 0xb1, 0xe3, 0xe7, 0x05, 0xe8, 0x7d,
+	0xe6, 0x58, 0x0c, 0x7a, 0xdf, 0x39, 0x6f, 0x0d, 0x3d, 0x6b, 0xa0, 0xf8, 0xba, 0xec, 0x10, 0x75,
+	0xb5, 0x76, 0x98, 0x80, 0xb4, 0x7c, 0x61, 0x3c, 0xae, 0xd1, 0x6d, 0x48, 0x88, 0xaa, 0xa7, 0x0b,
+	0xfe, 0xd0, 0x03, 0xdf, 0x05, 0xf9, 0x88, 0x07, 0x93, 0x3a, 0xfd, 0xc9, 0x0f, 0x2f, 0x9e, 0xc5,
+	0xc6, 0x55, 0x28, 0x8b, 0xc9, 0xb2, 0xa5, 0x3d, 0xe6, 0xd7, 0xc8, 0x2a, 0xbd, 0x05, 0x09, 0x91,
+	0xea, 0xa0, 0xc5, 0xc0, 0x4b, 0x3f, 0xd2, 0x22, 0x95, 0x16, 0x47, 0x28, 0x5a, 0xfc, 0x50, 0xaf,
+	0x3f, 0xa5, 0x55, 0x48, 0x23, 0x73, 0x74, 0x31, 0x6c, 0x31, 0xf0, 0xd8, 0xcf, 0xab, 0x27, 0x41,
+	0x1c, 0xaa, 0xd5, 0x51, 0xe9, 0x25, 0x4d, 0x93, 0xd2, 0x0b, 0xbd, 0x0f, 0x29, 0x27, 0x83, 0xb4,
+	0xe0, 0xdf, 0x1c, 0x7a, 0xc9, 0xe6, 0x67, 0x42, 0x4f, 0x9c, 0xff, 0x88, 0x1f, 0x8c, 0xdc, 0xb8,
+	0x57, 0xbd, 0x71, 0xd7, 0x20, 0xe5, 0xf4, 0x67, 0x5a, 0x0c, 0xa4, 0x36, 0xf4, 0xe6, 0xcd, 0x2f,
+	0x9e, 0x80, 0xc0, 0xa0, 0xa7, 0xa4, 0x8b, 0x31, 0x3a, 0x82, 0xc9, 0x76, 0x4c, 0x6b, 0x90, 0x71,
+	0x7b, 0x7d, 0x30, 0x3b, 0x11, 0xaf, 0xc4, 0xc8, 0x9c, 0x9f, 0x93, 0x86, 0x15, 0x75, 0xb2, 0x1f,
+	0xfb, 0xb5, 0x2e, 0xee, 0xbd, 0x46, 0x56, 0xd7, 0x5e, 0x24, 0xc0, 0x2d, 0x01, 0xa1, 0x97, 0xff,
+	0xa3, 0x5e, 0x8a, 0x61, 0xbd, 0xf8, 0x6f, 0x9b, 0x7c, 0xf4, 0x75, 0xaa, 0x2a, 0xd2, 0x1f, 0x55,
+	0x47, 0xdd, 0x1f, 0xef, 0x7a, 0xc2, 0xd9, 0x41, 0xe1, 0x14, 0xc3, 0x1c, 0x0e, 0x67, 0x1a, 0x05,
+	0x49, 0xfb, 0xa6, 0x25, 0x13, 0x0f, 0xfa, 0x0a, 0xba, 0x10, 0x29, 0x8f, 0x60, 0x4b, 0xcb, 0x2f,
+	0x9f, 0x06, 0x43, 0x52, 0x26, 0xa4, 0x43, 0xa0, 0x19, 0xd7, 0x21, 0x7d, 0xb7, 0x27, 0x26, 0x35,
+	0x4a, 0x4c, 0x81, 0x73, 0x0c, 0xd2, 0x13, 0x1e, 0x64, 0x35, 0x70, 0x10, 0xa3, 0x27, 0xa9, 0xa5,
+	0x28, 0xc1, 0x84, 0x8e, 0x71, 0xe1, 0x14, 0x14, 0x9e, 0x62, 0x56, 0x7a, 0x3b, 0x43, 0xc7, 0xfb,
+	0x8c, 0x38, 0x5e, 0x1e, 0x78, 0xd4, 0xb5, 0x14, 0xad, 0xae, 0xe1, 0x88, 0x59, 0x94, 0x1e, 0xe6,
+	0xd4, 0x19, 0xdf, 0x79, 0x7c, 0x32, 0xfb, 0x39, 0x01, 0x63, 0x9e, 0x16, 0x26, 0xa4, 0xb6, 0x87,
+	0x52, 0x5b, 0x1a, 0x28, 0x35, 0xaf, 0xb6, 0x07, 0xdf, 0xab, 0xea, 0x82, 0x74, 0x3f, 0xab, 0x52,
+	0xd7, 0xfd, 0x65, 0x5f, 0xc3, 0xaa, 0xa1, 0xee, 0x96, 0x06, 0xea, 0x6e, 0x48, 0x3f, 0x79, 0xe9,
+	0x67, 0x8a, 0x06, 0xfc, 0x48, 0xee, 0x3e, 0xee, 0xff, 0x3a, 0x71, 0xc3, 0x7b, 0xcb, 0xaf, 0x9c,
+	0x20, 0x35, 0xdf, 0x73, 0x27, 0x7f, 0x71, 0x08, 0x24, 0x32, 0x1a, 0x2a, 0x04, 0x19, 0x08, 0x7d,
+	0x46, 0x60, 0x3a, 0xf2, 0x16, 0xa4, 0xab, 0x83, 0xdb, 0x66, 0x48, 0x55, 0x97, 0x86, 0xc2, 0x62,
+	0x24, 0xc8, 0x3c, 0x3d, 0x8b, 0xdd, 0x05, 0x5f, 0x0a, 0x4f, 0xcb, 0xfd, 0xcf, 0x95, 0x7a, 0xaf,
+	0x64, 0x96, 0x4f, 0x28, 0x99, 0x61, 0xda, 0x30, 0xe6, 0x7f, 0x35, 0x22, 0xff, 0x95, 0x89, 0x77,
+	0xc6, 0xbc, 0xc6, 0xdb, 0xbb, 0xbb, 0x29, 0xb9, 0x7b, 0xfd, 0xb7, 0x01, 0x00, 0xd1, 0xe3, 0x86,
+	0x2f, 0x52, 0x18, 0x00, 0x00,
 }
@@ -0,0 +1,151 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: options/options.proto
+
+/*
+Package optionspb is a generated protocol buffer package.
+
+It is generated from these files:
+	options/options.proto
+
+It has these top-level messages:
+	FieldRules
+*/
+package optionspb
+
+import proto "github.com/gogo/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import google_protobuf "github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// FieldRules are the validation rules of a field. Rules other than required
+// apply to each element of repeated fields, and are skipped for fields left
+// at their zero value.
+type FieldRules struct {
+	// The field must be set: non empty strings, bytes and repeated fields,
+	// non zero numbers and enums, present messages.
+	Required bool `protobuf:"varint,1,opt,name=required,proto3" json:"required,omitempty"`
+	// Bounds of the length of strings, in characters
+	MinLen uint32 `protobuf:"varint,2,opt,name=min_len,json=minLen,proto3" json:"min_len,omitempty"`
+	MaxLen uint32 `protobuf:"varint,3,opt,name=max_len,json=maxLen,proto3" json:"max_len,omitempty"`
+	// An RE2 regular expression strings must match
+	Pattern string `protobuf:"bytes,4,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	// Strings must be email addresses
+	Email bool `protobuf:"varint,5,opt,name=email,proto3" json:"email,omitempty"`
+	// Strings must be one of these values
+	In []string `protobuf:"bytes,6,rep,name=in" json:"in,omitempty"`
+	// Enums must be one of the values declared by their enum type
+	DefinedOnly bool `protobuf:"varint,7,opt,name=defined_only,json=definedOnly,proto3" json:"defined_only,omitempty"`
+}
+
+func (m *FieldRules) Reset()                    { *m = FieldRules{} }
+func (m *FieldRules) String() string            { return proto.CompactTextString(m) }
+func (*FieldRules) ProtoMessage()               {}
+func (*FieldRules) Descriptor() ([]byte, []int) { return fileDescriptorOptions, []int{0} }
+
+func (m *FieldRules) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+func (m *FieldRules) GetMinLen() uint32 {
+	if m != nil {
+		return m.MinLen
+	}
+	return 0
+}
+
+func (m *FieldRules) GetMaxLen() uint32 {
+	if m != nil {
+		return m.MaxLen
+	}
+	return 0
+}
+
+func (m *FieldRules) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *FieldRules) GetEmail() bool {
+	if m != nil {
+		return m.Email
+	}
+	return false
+}
+
+func (m *FieldRules) GetIn() []string {
+	if m != nil {
+		return m.In
+	}
+	return nil
+}
+
+func (m *FieldRules) GetDefinedOnly() bool {
+	if m != nil {
+		return m.DefinedOnly
+	}
+	return false
+}
+
+var E_Sensitive = &proto.ExtensionDesc{
+	ExtendedType:  (*google_protobuf.FieldOptions)(nil),
+	ExtensionType: (*bool)(nil),
+	Field:         50100,
+	Name:          "options.sensitive",
+	Tag:           "varint,50100,opt,name=sensitive",
+	Filename:      "options/options.proto",
+}
+
+var E_Rules = &proto.ExtensionDesc{
+	ExtendedType:  (*google_protobuf.FieldOptions)(nil),
+	ExtensionType: (*FieldRules)(nil),
+	Field:         50101,
+	Name:          "options.rules",
+	Tag:           "bytes,50101,opt,name=rules",
+	Filename:      "options/options.proto",
+}
+
+func init() {
+	proto.RegisterType((*FieldRules)(nil), "options.FieldRules")
+	proto.RegisterExtension(E_Sensitive)
+	proto.RegisterExtension(E_Rules)
+}
+
+func init() { proto.RegisterFile("options/options.proto", fileDescriptorOptions) }
+
+var fileDescriptorOptions = []byte{
+	// 281 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0x31, 0x4b, 0xc4, 0x40,
+	0x10, 0x85, 0xc9, 0xc5, 0x24, 0x97, 0x8d, 0x5a, 0xac, 0x8a, 0xcb, 0x81, 0x10, 0xad, 0x52, 0x25,
+	0xa0, 0xdd, 0x81, 0x8d, 0x85, 0x85, 0x08, 0x07, 0x5b, 0xda, 0x1c, 0x89, 0x99, 0x3b, 0x06, 0x36,
+	0xb3, 0x71, 0xb3, 0x91, 0xbb, 0x5f, 0xe0, 0x1f, 0xd2, 0xd2, 0xff, 0x26, 0x6e, 0x12, 0xaf, 0xb4,
+	0x5a, 0xde, 0x7c, 0x33, 0x6f, 0x79, 0x8f, 0x5d, 0xe8, 0xd6, 0xa2, 0xa6, 0xae, 0x18, 0xdf, 0xbc,
+	0x35, 0xda, 0x6a, 0x1e, 0x8d, 0x72, 0x91, 0x6e, 0xb5, 0xde, 0x2a, 0x28, 0xdc, 0xb8, 0xea, 0x37,
+	0x45, 0x0d, 0xdd, 0xab, 0xc1, 0xd6, 0x6a, 0x33, 0xac, 0xde, 0x7c, 0x7b, 0x8c, 0x3d, 0x22, 0xa8,
+	0x5a, 0xf6, 0x0a, 0x3a, 0xbe, 0x60, 0x73, 0x03, 0x6f, 0x3d, 0x1a, 0xa8, 0x85, 0x97, 0x7a, 0xd9,
+	0x5c, 0xfe, 0x69, 0x7e, 0xc9, 0xa2, 0x06, 0x69, 0xad, 0x80, 0xc4, 0x2c, 0xf5, 0xb2, 0x13, 0x19,
+	0x36, 0x48, 0xcf, 0x40, 0x0e, 0x94, 0x3b, 0x07, 0xfc, 0x11, 0x94, 0xbb, 0x5f, 0x20, 0x58, 0xd4,
+	0x96, 0xd6, 0x82, 0x21, 0x71, 0x94, 0x7a, 0x59, 0x2c, 0x27, 0xc9, 0xcf, 0x59, 0x00, 0x4d, 0x89,
+	0x4a, 0x04, 0xee, 0x93, 0x41, 0xf0, 0x53, 0x36, 0x43, 0x12, 0x61, 0xea, 0x67, 0xb1, 0x9c, 0x21,
+	0xf1, 0x6b, 0x76, 0x5c, 0xc3, 0x06, 0x09, 0xea, 0xb5, 0x26, 0xb5, 0x17, 0x91, 0x5b, 0x4e, 0xc6,
+	0xd9, 0x8a, 0xd4, 0x7e, 0x79, 0xcf, 0xe2, 0x0e, 0xa8, 0x43, 0x8b, 0xef, 0xc0, 0xaf, 0xf2, 0x21,
+	0x6f, 0x3e, 0xe5, 0xcd, 0x5d, 0xb4, 0xd5, 0xd0, 0x86, 0xf8, 0xfc, 0xf0, 0x9d, 0xc1, 0xe1, 0x62,
+	0xf9, 0xc4, 0x02, 0xe3, 0x82, 0xff, 0x73, 0xfa, 0xe5, 0x4e, 0x93, 0xdb, 0xb3, 0x7c, 0x6a, 0xfa,
+	0x50, 0x9a, 0x1c, 0x2c, 0x1e, 0x92, 0x97, 0x78, 0x84, 0x6d, 0x55, 0x85, 0xce, 0xe7, 0xee, 0x67,
+	0x00, 0xdf, 0x40, 0x81, 0xc1, 0xa2, 0x01, 0x00, 0x00,
+}
@@ -2,28 +2,110 @@ package app
 
 import (
 	"fmt"
+	"net/http"
 
 	"github.com/imdario/mergo"
+	"github.com/rs/cors"
+
+	"github.com/nathanows/elegant-monolith/pkg/auth"
+	"github.com/nathanows/elegant-monolith/pkg/errreport"
+	"github.com/nathanows/elegant-monolith/pkg/etag"
+	"github.com/nathanows/elegant-monolith/pkg/grpcweb"
+	"github.com/nathanows/elegant-monolith/pkg/idempotency"
+	"github.com/nathanows/elegant-monolith/pkg/logging"
+	"github.com/nathanows/elegant-monolith/pkg/pagination"
+	"github.com/nathanows/elegant-monolith/pkg/ratelimit"
+	"github.com/nathanows/elegant-monolith/pkg/requestid"
+	"github.com/nathanows/elegant-monolith/pkg/validation"
 )
 
 // The Config struct wraps the available application level config. Viper is used
 // to marshal config files/env vars/flags to Config
 type Config struct {
-	GRPCAddr       int
-	HTTPAddr       int
-	DatabaseConfig DatabaseConfig
+	GRPCAddr int
+	HTTPAddr int
+	// Addr, when set, serves gRPC, REST and gRPC-Web on this single port
+	// instead of GRPCAddr and HTTPAddr
+	Addr            int
+	DatabaseConfig  DatabaseConfig
+	APIKeyConfig    APIKeyConfig
+	RateLimitConfig ratelimit.Config
+	CORSConfig      CORSConfig
+	AdminConfig     AdminConfig
+	LogConfig       logging.Config
+	RedactConfig    RedactConfig
+	// ErrorReportConfig selects where unhandled errors and panics are
+	// reported, on top of being logged
+	ErrorReportConfig errreport.Config
+	// ValidationConfig holds the lists backing the validation rules of each
+	// module, keyed by module name. It is reloaded on SIGHUP.
+	ValidationConfig map[string]validation.Config
+	// PaginationConfig bounds the page size of listings
+	PaginationConfig pagination.Config
+	// IdempotencyConfig controls how long idempotency keys of create requests
+	// are remembered
+	IdempotencyConfig idempotency.Config
+	// SoftDeleteConfig controls how long deleted companies can be restored
+	SoftDeleteConfig SoftDeleteConfig
 }
 
 // DatabaseConfig is an environment agnostic config struct for DB setup
 type DatabaseConfig struct {
 	Username string
-	Password string
+	Password string `secret:"true"`
 	Hostname string
 	Database string
 	Port     int32
 	Sslmode  string
 }
 
+// SoftDeleteConfig controls the purge of soft deleted resources
+type SoftDeleteConfig struct {
+	// Retention is how long, in seconds, deleted resources are kept before
+	// being purged for good. Purging is disabled when zero.
+	Retention int
+}
+
+// APIKeyConfig controls how machine clients authenticate with API keys
+type APIKeyConfig struct {
+	// Required rejects requests that don't present an API key. When false,
+	// keys are still verified when presented.
+	Required bool
+	// Bootstrap lets unauthenticated callers create, list and revoke the keys
+	// of any company, to issue the first keys of a deployment. Keys can only
+	// be managed with a key of the same company otherwise.
+	Bootstrap bool
+}
+
+// CORSConfig controls which browser origins may call the HTTP listener, REST
+// and gRPC-Web routes alike
+type CORSConfig struct {
+	// AllowedOrigins may contain one wildcard per origin, e.g.
+	// https://*.example.com. CORS is disabled when empty.
+	AllowedOrigins []string
+	// AllowedHeaders are request headers allowed on top of the ones gRPC-Web
+	// clients need
+	AllowedHeaders   []string
+	AllowCredentials bool
+	// MaxAge is how long, in seconds, browsers may cache preflight results
+	MaxAge int
+}
+
+// AdminConfig controls the listener serving operational endpoints
+type AdminConfig struct {
+	// Addr is a host:port, e.g. 127.0.0.1:9090 to only accept local
+	// connections. The admin listener is disabled when empty.
+	Addr string
+}
+
+// RedactConfig controls the masking of fields marked (options.sensitive) in
+// logs and error details
+type RedactConfig struct {
+	// AllowSensitive logs personal data and secrets in clear, for local
+	// development only
+	AllowSensitive bool
+}
+
 // BuildDbConnectionStr returns a postgres compliant connection string
 func (dbConfig DatabaseConfig) BuildDbConnectionStr() string {
 	defaultConfig := &DatabaseConfig{Password: "", Hostname: "localhost", Database: "elegant-monolith", Port: 5432, Sslmode: "disable"}
@@ -34,3 +116,20 @@ func (dbConfig DatabaseConfig) BuildDbConnectionStr() string {
 
 	return connectionStr
 }
+
+// Handler wraps next with CORS handling, answering preflight requests and
+// adding the access control headers to actual ones
+func (corsConfig CORSConfig) Handler(next http.Handler) http.Handler {
+	if len(corsConfig.AllowedOrigins) == 0 {
+		return next
+	}
+
+	return cors.New(cors.Options{
+		AllowedOrigins:   corsConfig.AllowedOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+		AllowedHeaders:   append(append([]string{auth.APIKeyHeader, requestid.Header, etag.IfMatchHeader, idempotency.Header}, grpcweb.RequestHeaders...), corsConfig.AllowedHeaders...),
+		ExposedHeaders:   append([]string{requestid.Header, "Retry-After", etag.Header, "Idempotent-Replayed"}, grpcweb.ResponseHeaders...),
+		AllowCredentials: corsConfig.AllowCredentials,
+		MaxAge:           corsConfig.MaxAge,
+	}).Handler(next)
+}
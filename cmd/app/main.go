@@ -1,15 +1,18 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/log"
-	"github.com/gorilla/mux"
+	"github.com/go-kit/kit/log/level"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq" // Postgres driver
 	"github.com/oklog/oklog/pkg/group"
@@ -17,10 +20,31 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	apikeypb "github.com/nathanows/elegant-monolith/_protos/apikeys"
 	pb "github.com/nathanows/elegant-monolith/_protos/companyusers"
+	apikeyservice "github.com/nathanows/elegant-monolith/internal/apikey/service"
+	apikeytransport "github.com/nathanows/elegant-monolith/internal/apikey/transport"
 	companyservice "github.com/nathanows/elegant-monolith/internal/company/service"
 	companytransport "github.com/nathanows/elegant-monolith/internal/company/transport"
+	"github.com/nathanows/elegant-monolith/pkg/accesslog"
+	"github.com/nathanows/elegant-monolith/pkg/admin"
+	"github.com/nathanows/elegant-monolith/pkg/auth"
 	"github.com/nathanows/elegant-monolith/pkg/conf"
+	"github.com/nathanows/elegant-monolith/pkg/errreport"
+	"github.com/nathanows/elegant-monolith/pkg/grpcchain"
+	"github.com/nathanows/elegant-monolith/pkg/grpcweb"
+	"github.com/nathanows/elegant-monolith/pkg/httpcodec"
+	"github.com/nathanows/elegant-monolith/pkg/idempotency"
+	"github.com/nathanows/elegant-monolith/pkg/logging"
+	"github.com/nathanows/elegant-monolith/pkg/multiplex"
+	"github.com/nathanows/elegant-monolith/pkg/openapi"
+	"github.com/nathanows/elegant-monolith/pkg/pagination"
+	"github.com/nathanows/elegant-monolith/pkg/ratelimit"
+	"github.com/nathanows/elegant-monolith/pkg/recovery"
+	"github.com/nathanows/elegant-monolith/pkg/redact"
+	"github.com/nathanows/elegant-monolith/pkg/requestid"
+	"github.com/nathanows/elegant-monolith/pkg/transcode"
+	"github.com/nathanows/elegant-monolith/pkg/validation"
 )
 
 var rootCmd = &cobra.Command{
@@ -38,6 +62,7 @@ func Execute() {
 }
 
 func run(cmd *cobra.Command, args []string) {
+	// replaced by the configured root logger once the config is loaded
 	var logger log.Logger
 	{
 		logger = log.NewJSONLogger(os.Stderr)
@@ -59,6 +84,28 @@ func run(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	var loggers *logging.Loggers
+	{
+		var err error
+		loggers, err = logging.New(os.Stderr, config.LogConfig)
+		if err != nil {
+			logger.Log("config", "load_err", "during", "logging.New", "err", err)
+			os.Exit(1)
+		}
+		logger = loggers.Root()
+		redact.SetEnabled(!config.RedactConfig.AllowSensitive)
+	}
+
+	var reporter errreport.Reporter
+	{
+		var err error
+		reporter, err = errreport.New(config.ErrorReportConfig, logger)
+		if err != nil {
+			logger.Log("config", "load_err", "during", "errreport.New", "err", err)
+			os.Exit(1)
+		}
+	}
+
 	var db *sqlx.DB
 	{
 		var err error
@@ -70,47 +117,189 @@ func run(cmd *cobra.Command, args []string) {
 		defer db.Close()
 	}
 
+	// validation rules of each module, reloaded on SIGHUP
+	var companyRules *validation.Registry
+	{
+		var err error
+		companyRules, err = companyservice.NewRules(config.ValidationConfig["company"])
+		if err != nil {
+			logger.Log("config", "load_err", "during", "companyservice.NewRules", "err", err)
+			os.Exit(1)
+		}
+	}
+	rules := map[string]*validation.Registry{"company": companyRules}
+
 	var (
-		companyHTTPServer, companyGRPCServer = buildCompanyServers(logger, db)
+		apiKeyService     = apikeyservice.NewService(loggers.Module("apikey"), reporter, apikeyservice.NewRepository(db), config.APIKeyConfig.Bootstrap)
+		authMiddleware    = auth.Middleware(apikeytransport.NewAuthenticator(apiKeyService), config.APIKeyConfig.Required)
+		limiter           = ratelimit.NewLimiter(config.RateLimitConfig)
+		idempotencyKeys   = idempotency.NewKeys(idempotency.NewPostgresStore(db), config.IdempotencyConfig, loggers.Module("idempotency"))
+		apiKeyGRPCServer  = buildAPIKeyServer(loggers.Module("apikey"), apiKeyService, authMiddleware, limiter)
+		companyGRPCServer = buildCompanyServer(loggers.Module("company"), reporter, db, companyRules, config.PaginationConfig, authMiddleware, limiter, idempotencyKeys)
 	)
 
+	if config.APIKeyConfig.Bootstrap {
+		level.Warn(logger).Log("config", "apiKeyConfig.bootstrap", "msg", "anyone can manage the API keys of any company, disable once the first keys are issued")
+	}
+
+	// modules registered with the monolith, by the gRPC services they expose
+	modules := []admin.Module{
+		{Name: "company", Services: []string{"companyusers.CompanySvc"}},
+		{Name: "apikey", Services: []string{"apikeys.APIKeySvc"}},
+	}
+
+	accessLogger := loggers.Module("access")
+
+	var grpcAPI *grpc.Server
+	{
+		grpcAPI = grpc.NewServer(
+			grpc.UnaryInterceptor(grpcchain.UnaryServer(
+				requestid.UnaryServerInterceptor(),
+				accesslog.UnaryServerInterceptor(accessLogger),
+				recovery.UnaryServerInterceptor(logger, reporter),
+			)),
+			grpc.StreamInterceptor(grpcchain.StreamServer(
+				requestid.StreamServerInterceptor(),
+				recovery.StreamServerInterceptor(logger, reporter),
+			)),
+		)
+		pb.RegisterCompanySvcServer(grpcAPI, companyGRPCServer)
+		apikeypb.RegisterAPIKeySvcServer(grpcAPI, apiKeyGRPCServer)
+		reflection.Register(grpcAPI)
+	}
+
 	var httpAPI http.Handler
 	{
-		r := mux.NewRouter()
-		r.PathPrefix("/company/").Handler(http.StripPrefix("/company", companyHTTPServer))
-		httpAPI = r
+		servers := map[string]interface{}{
+			"companyusers.CompanySvc": companyGRPCServer,
+			"apikeys.APIKeySvc":       apiKeyGRPCServer,
+		}
+		transcoder := transcode.NewHandler()
+		for _, service := range apiServices {
+			if err := transcoder.Register(service.File, service.Name, servers[service.Name]); err != nil {
+				logger.Log("transport", "HTTP", "during", "Register", "service", service.Name, "err", err)
+				os.Exit(1)
+			}
+		}
+		doc, err := openAPIDocument()
+		if err != nil {
+			logger.Log("transport", "HTTP", "during", "openAPIDocument", "err", err)
+			os.Exit(1)
+		}
+		specHandler, err := openapi.Handler(doc)
+		if err != nil {
+			logger.Log("transport", "HTTP", "during", "openapi.Handler", "err", err)
+			os.Exit(1)
+		}
+
+		m := http.NewServeMux()
+		m.Handle("/openapi.json", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accesslog.SetRoute(r.Context(), "/openapi.json")
+			specHandler.ServeHTTP(w, r)
+		}))
+		m.Handle("/", transcoder)
+		httpAPI = recovery.HTTPMiddleware(logger, reporter)(m)
+		httpAPI = httpcodec.GzipMiddleware(httpAPI)
+		// browsers reach the gRPC server through gRPC-Web on the HTTP listener
+		httpAPI = grpcweb.Middleware(grpcAPI)(httpAPI)
+		httpAPI = config.CORSConfig.Handler(httpAPI)
+		httpAPI = accesslog.HTTPMiddleware(accessLogger)(httpAPI)
+		httpAPI = requestid.HTTPMiddleware(httpAPI)
 	}
 
 	var g group.Group
-	{
-		port := fmt.Sprintf(":%d", config.HTTPAddr)
-		httpListener, err := net.Listen("tcp", port)
+	if config.Addr != 0 {
+		port := fmt.Sprintf(":%d", config.Addr)
+		listener, err := net.Listen("tcp", port)
 		if err != nil {
-			logger.Log("transport", "HTTP", "during", "Listen", "err", err)
+			logger.Log("transport", "HTTP/gRPC", "during", "Listen", "err", err)
 			os.Exit(1)
 		}
 		g.Add(func() error {
-			logger.Log("transport", "HTTP", "addr", port)
-			return http.Serve(httpListener, httpAPI)
+			logger.Log("transport", "HTTP/gRPC", "addr", port)
+			return http.Serve(listener, multiplex.Handler(grpcAPI, httpAPI))
 		}, func(error) {
-			httpListener.Close()
+			listener.Close()
 		})
+	} else {
+		{
+			port := fmt.Sprintf(":%d", config.HTTPAddr)
+			httpListener, err := net.Listen("tcp", port)
+			if err != nil {
+				logger.Log("transport", "HTTP", "during", "Listen", "err", err)
+				os.Exit(1)
+			}
+			g.Add(func() error {
+				logger.Log("transport", "HTTP", "addr", port)
+				return http.Serve(httpListener, httpAPI)
+			}, func(error) {
+				httpListener.Close()
+			})
+		}
+		{
+			port := fmt.Sprintf(":%d", config.GRPCAddr)
+			grpcListener, err := net.Listen("tcp", port)
+			if err != nil {
+				logger.Log("transport", "gRPC", "during", "Listen", "err", err)
+				os.Exit(1)
+			}
+			g.Add(func() error {
+				logger.Log("transport", "gRPC", "addr", port)
+				return grpcAPI.Serve(grpcListener)
+			}, func(error) {
+				grpcListener.Close()
+			})
+		}
 	}
-	{
-		port := fmt.Sprintf(":%d", config.GRPCAddr)
-		grpcListener, err := net.Listen("tcp", port)
+	if config.AdminConfig.Addr != "" {
+		adminListener, err := net.Listen("tcp", config.AdminConfig.Addr)
 		if err != nil {
-			logger.Log("transport", "gRPC", "during", "Listen", "err", err)
+			logger.Log("transport", "admin", "during", "Listen", "err", err)
 			os.Exit(1)
 		}
+		adminAPI := admin.NewHandler(config, db, modules)
+		g.Add(func() error {
+			logger.Log("transport", "admin", "addr", config.AdminConfig.Addr)
+			return http.Serve(adminListener, adminAPI)
+		}, func(error) {
+			adminListener.Close()
+		})
+	}
+	{
+		ctx, cancel := context.WithCancel(context.Background())
+		g.Add(func() error {
+			idempotencyKeys.PurgeExpired(ctx)
+			return nil
+		}, func(error) {
+			cancel()
+		})
+	}
+	if config.SoftDeleteConfig.Retention > 0 {
+		retention := time.Duration(config.SoftDeleteConfig.Retention) * time.Second
+		ctx, cancel := context.WithCancel(context.Background())
 		g.Add(func() error {
-			logger.Log("transport", "gRPC", "addr", port)
-			baseServer := grpc.NewServer()
-			pb.RegisterCompanySvcServer(baseServer, companyGRPCServer)
-			reflection.Register(baseServer)
-			return baseServer.Serve(grpcListener)
+			companyservice.PurgeDeleted(ctx, companyservice.NewRepository(db), retention, loggers.Module("company"))
+			return nil
 		}, func(error) {
-			grpcListener.Close()
+			cancel()
+		})
+	}
+	{
+		cancelReload := make(chan struct{})
+		g.Add(func() error {
+			c := make(chan os.Signal, 1)
+			signal.Notify(c, syscall.SIGHUP)
+			defer signal.Stop(c)
+			for {
+				select {
+				case <-c:
+					reloadRules(cmd, logger, rules)
+				case <-cancelReload:
+					return nil
+				}
+			}
+		}, func(error) {
+			close(cancelReload)
 		})
 	}
 	{
@@ -132,12 +321,40 @@ func run(cmd *cobra.Command, args []string) {
 	logger.Log("exit", g.Run())
 }
 
-func buildCompanyServers(logger log.Logger, db *sqlx.DB) (http.Handler, pb.CompanySvcServer) {
+// reloadRules reads the config again and hands each module's validation
+// config to its rules. Modules whose config fails to load keep their rules.
+func reloadRules(cmd *cobra.Command, logger log.Logger, rules map[string]*validation.Registry) {
+	config := &Config{}
+	parser, err := conf.NewParser(cmd, config, conf.EnvPrefix("EM"))
+	if err == nil {
+		err = parser.LoadConfig()
+	}
+	if err != nil {
+		level.Error(logger).Log("config", "reload_err", "during", "LoadConfig", "err", err)
+		return
+	}
+	for module, registry := range rules {
+		if err := registry.Reload(config.ValidationConfig[module]); err != nil {
+			level.Error(logger).Log("config", "reload_err", "module", module, "during", "Reload", "err", err)
+			continue
+		}
+		level.Info(logger).Log("config", "reloaded", "module", module)
+	}
+}
+
+// The gRPC servers are the single implementation of each service, the HTTP
+// API transcodes to them from their google.api.http annotations.
+
+func buildCompanyServer(logger log.Logger, reporter errreport.Reporter, db *sqlx.DB, rules *validation.Registry, paging pagination.Config, authMiddleware endpoint.Middleware, limiter *ratelimit.Limiter, keys *idempotency.Keys) pb.CompanySvcServer {
 	repository := companyservice.NewRepository(db)
-	service := companyservice.NewService(logger, repository)
-	endpoints := companytransport.NewEndpointSet(service, logger)
-	grpcServer := companytransport.NewGRPCServer(endpoints, logger)
-	httpServer := companytransport.NewHTTPServer(endpoints, logger)
+	service := companyservice.NewService(logger, reporter, repository, rules, paging)
+	endpoints := companytransport.NewEndpointSet(service, logger, authMiddleware, limiter, keys)
+
+	return companytransport.NewGRPCServer(endpoints, logger)
+}
+
+func buildAPIKeyServer(logger log.Logger, service apikeyservice.Service, authMiddleware endpoint.Middleware, limiter *ratelimit.Limiter) apikeypb.APIKeySvcServer {
+	endpoints := apikeytransport.NewEndpointSet(service, logger, authMiddleware, limiter)
 
-	return httpServer, grpcServer
+	return apikeytransport.NewGRPCServer(endpoints, logger)
 }
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nathanows/elegant-monolith/pkg/openapi"
+)
+
+// apiServices are the gRPC services whose HTTP mapping makes up the REST
+// API, registered with the transcoder and documented by the OpenAPI document
+var apiServices = []openapi.Service{
+	{File: "companyusers/companyusers.proto", Name: "companyusers.CompanySvc"},
+	{File: "apikeys/apikeys.proto", Name: "apikeys.APIKeySvc"},
+}
+
+var specCmd = &cobra.Command{
+	Use:   "spec",
+	Short: "Writes the OpenAPI document of the REST API to disk.",
+	Run:   spec,
+}
+
+func init() {
+	specCmd.Flags().StringP("out", "o", "openapi.json", "file the document is written to")
+	rootCmd.AddCommand(specCmd)
+}
+
+func spec(cmd *cobra.Command, args []string) {
+	doc, err := openAPIDocument()
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	body, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	out, _ := cmd.Flags().GetString("out")
+	if err := ioutil.WriteFile(out, append(body, '\n'), 0644); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	fmt.Println("wrote", out)
+}
+
+func openAPIDocument() (*openapi.Document, error) {
+	return openapi.Generate(openapi.Info{
+		Title:       "elegant-monolith",
+		Description: "REST mapping of the elegant-monolith gRPC services.",
+		Version:     "v1",
+	}, apiServices...)
+}
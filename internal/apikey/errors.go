@@ -0,0 +1,45 @@
+package apikey
+
+import (
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+)
+
+// API Key Service Error descriptions
+const (
+	ErrorRequireCompany = "missing required company id"
+	ErrorRequireName    = "missing required name"
+	ErrorInvalidScope   = "invalid scope, expected resource:action"
+	ErrorInvalidExpiry  = "expiry must be in the future"
+	ErrorKeyNotFound    = "api key not found"
+	ErrorInvalidKey     = "invalid api key"
+	ErrorExpiredKey     = "api key expired"
+	ErrorRevokedKey     = "api key revoked"
+	ErrorRepository     = "unable to query repository"
+)
+
+// API Key Service Error reasons
+const (
+	ReasonRequireCompany = "API_KEY_COMPANY_REQUIRED"
+	ReasonRequireName    = "API_KEY_NAME_REQUIRED"
+	ReasonInvalidScope   = "API_KEY_SCOPE_INVALID"
+	ReasonInvalidExpiry  = "API_KEY_EXPIRY_INVALID"
+	ReasonKeyNotFound    = "API_KEY_NOT_FOUND"
+	ReasonInvalidKey     = "API_KEY_INVALID"
+	ReasonExpiredKey     = "API_KEY_EXPIRED"
+	ReasonRevokedKey     = "API_KEY_REVOKED"
+	ReasonRepository     = "API_KEY_REPOSITORY_UNAVAILABLE"
+)
+
+// API Key Service Errors
+var (
+	ErrRequireCompany = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonRequireCompany, ErrorRequireCompany)
+	ErrRequireName    = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonRequireName, ErrorRequireName)
+	ErrInvalidScope   = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonInvalidScope, ErrorInvalidScope)
+	ErrInvalidExpiry  = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonInvalidExpiry, ErrorInvalidExpiry)
+	ErrKeyNotFound    = apierror.New(codespb.Code_NOT_FOUND, ReasonKeyNotFound, ErrorKeyNotFound)
+	ErrInvalidKey     = apierror.New(codespb.Code_UNAUTHENTICATED, ReasonInvalidKey, ErrorInvalidKey)
+	ErrExpiredKey     = apierror.New(codespb.Code_UNAUTHENTICATED, ReasonExpiredKey, ErrorExpiredKey)
+	ErrRevokedKey     = apierror.New(codespb.Code_UNAUTHENTICATED, ReasonRevokedKey, ErrorRevokedKey)
+	ErrRepository     = apierror.New(codespb.Code_INTERNAL, ReasonRepository, ErrorRepository)
+)
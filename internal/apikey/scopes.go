@@ -0,0 +1,7 @@
+package apikey
+
+// Scopes granting management of a company's API keys
+const (
+	ScopeRead  = "apikeys:read"
+	ScopeWrite = "apikeys:write"
+)
@@ -0,0 +1,17 @@
+CREATE TABLE api_keys (
+	id    serial PRIMARY KEY,
+	company_id integer NOT NULL,
+	name   varchar(80) NOT NULL CHECK (name <> ''),
+	prefix varchar(16) NOT NULL,
+	key_hash char(64) UNIQUE NOT NULL,
+	scopes text[] NOT NULL default '{}',
+	expires_at timestamp without time zone,
+	last_used_at timestamp without time zone,
+	revoked_at timestamp without time zone,
+	created_at timestamp without time zone NOT NULL default timezone('utc', now()),
+	updated_at timestamp without time zone NOT NULL default timezone('utc', now())
+);
+
+CREATE INDEX api_keys_company_id_idx ON api_keys (company_id);
+
+CREATE TRIGGER set_api_key_updated_at BEFORE UPDATE ON api_keys FOR EACH ROW EXECUTE PROCEDURE set_updated_at();
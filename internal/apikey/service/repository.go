@@ -0,0 +1,118 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Common errors
+var (
+	ErrRepository = errors.New("unable to handle request")
+	ErrNotFound   = errors.New("api key not found")
+)
+
+// Repository is the datastore inteface for the api key service
+type Repository interface {
+	save(*apiKeyDTO) (*apiKeyDTO, error)
+	find(int64) (*apiKeyDTO, error)
+	findByHash(string) (*apiKeyDTO, error)
+	findAllByCompany(int64) ([]*apiKeyDTO, error)
+	revoke(int64) error
+	touch(int64) error
+}
+
+type repository struct {
+	db *sqlx.DB
+}
+
+// NewRepository returns an initialized datastore repository
+func NewRepository(db *sqlx.DB) Repository {
+	return repository{
+		db: db,
+	}
+}
+
+func (r repository) save(apiKey *apiKeyDTO) (*apiKeyDTO, error) {
+	stmt, err := r.db.PrepareNamed(sqlInsertAPIKey)
+	if err != nil {
+		return nil, ErrRepository
+	}
+	defer stmt.Close()
+
+	var saved apiKeyDTO
+	if err := stmt.QueryRowx(apiKey).StructScan(&saved); err != nil {
+		return nil, ErrRepository
+	}
+
+	return &saved, nil
+}
+
+func (r repository) find(id int64) (*apiKeyDTO, error) {
+	return r.get(sqlFindAPIKey, id)
+}
+
+func (r repository) findByHash(hash string) (*apiKeyDTO, error) {
+	return r.get(sqlFindAPIKeyByHash, hash)
+}
+
+func (r repository) get(query string, arg interface{}) (*apiKeyDTO, error) {
+	var apiKey apiKeyDTO
+	if err := r.db.Get(&apiKey, query, arg); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, ErrRepository
+	}
+	return &apiKey, nil
+}
+
+func (r repository) findAllByCompany(companyID int64) ([]*apiKeyDTO, error) {
+	apiKeys := []*apiKeyDTO{}
+	if err := r.db.Select(&apiKeys, sqlFindAPIKeysByCompany, companyID); err != nil {
+		return nil, ErrRepository
+	}
+	return apiKeys, nil
+}
+
+func (r repository) revoke(id int64) error {
+	res, err := r.db.Exec(sqlRevokeAPIKey, id)
+	if err != nil {
+		return ErrRepository
+	}
+	if n, err := res.RowsAffected(); err != nil || n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r repository) touch(id int64) error {
+	if _, err := r.db.Exec(sqlTouchAPIKey, id); err != nil {
+		return ErrRepository
+	}
+	return nil
+}
+
+const sqlAPIKeyColumns = "id, company_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at, updated_at"
+
+const sqlInsertAPIKey = `
+	INSERT INTO api_keys (company_id, name, prefix, key_hash, scopes, expires_at)
+	VALUES (:company_id, :name, :prefix, :key_hash, :scopes, :expires_at)
+	RETURNING ` + sqlAPIKeyColumns + `;`
+
+const sqlFindAPIKey = "SELECT " + sqlAPIKeyColumns + " FROM api_keys WHERE id = $1"
+
+const sqlFindAPIKeyByHash = "SELECT " + sqlAPIKeyColumns + " FROM api_keys WHERE key_hash = $1"
+
+const sqlFindAPIKeysByCompany = "SELECT " + sqlAPIKeyColumns + " FROM api_keys WHERE company_id = $1 ORDER BY id"
+
+const sqlRevokeAPIKey = `
+	UPDATE api_keys SET revoked_at = timezone('utc', now())
+	WHERE id = $1 AND revoked_at IS NULL;`
+
+// last_used_at is only written once a minute per key so authenticating every
+// request doesn't turn into a write on every request
+const sqlTouchAPIKey = `
+	UPDATE api_keys SET last_used_at = timezone('utc', now())
+	WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < timezone('utc', now()) - interval '1 minute');`
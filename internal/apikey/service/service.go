@@ -0,0 +1,295 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/gogo/protobuf/types"
+	"github.com/lib/pq"
+
+	pb "github.com/nathanows/elegant-monolith/_protos/apikeys"
+	"github.com/nathanows/elegant-monolith/internal/apikey"
+	"github.com/nathanows/elegant-monolith/pkg/auth"
+	"github.com/nathanows/elegant-monolith/pkg/errreport"
+)
+
+// Service interface defines the core API Key service functionality
+type Service interface {
+	Create(ctx context.Context, apiKey *pb.APIKey) (*pb.APIKey, string, error)
+	List(ctx context.Context, companyID int64) ([]*pb.APIKey, error)
+	Revoke(ctx context.Context, id int64) error
+	Authenticate(ctx context.Context, key string) (*pb.APIKey, error)
+}
+
+// NewService returns an initialized Service wired up with all middleware.
+// bootstrap lets unauthenticated callers manage the keys of any company, to
+// issue the first keys of a deployment.
+func NewService(logger log.Logger, reporter errreport.Reporter, repository Repository, bootstrap bool) Service {
+	var svc Service
+	{
+		svc = NewBasicService(repository, bootstrap)
+		svc = ServiceErrorReportingMiddleware(reporter)(svc)
+		svc = ServiceLoggingMiddleware(logger)(svc)
+	}
+	return svc
+}
+
+// NewBasicService returns an initialized Service without middleware
+func NewBasicService(repository Repository, bootstrap bool) Service {
+	return basicService{
+		repository: repository,
+		bootstrap:  bootstrap,
+	}
+}
+
+type basicService struct {
+	repository Repository
+	bootstrap  bool
+}
+
+// keys look like em_<prefix>_<secret>, only the prefix is stored in the clear
+const (
+	keyScheme      = "em"
+	keyPrefixBytes = 4
+	keySecretBytes = 24
+)
+
+var scopeRegexp = regexp.MustCompile(`^[a-z_]+:[a-z_]+$`)
+
+func (s basicService) Create(ctx context.Context, apiKey *pb.APIKey) (*pb.APIKey, string, error) {
+	if apiKey.GetCompanyID() == 0 {
+		return nil, "", apikey.ErrRequireCompany
+	}
+	if err := s.authorizeCompany(ctx, apiKey.CompanyID); err != nil {
+		return nil, "", err
+	}
+	if strings.TrimSpace(apiKey.Name) == "" {
+		return nil, "", apikey.ErrRequireName
+	}
+	principal, authenticated := auth.FromContext(ctx)
+	for _, scope := range apiKey.Scopes {
+		if !scopeRegexp.MatchString(scope) {
+			return nil, "", apikey.ErrInvalidScope
+		}
+		// a key can't be used to mint keys more powerful than itself
+		if authenticated && !principal.HasScope(scope) {
+			return nil, "", auth.ErrInsufficientScope
+		}
+	}
+
+	dto := toDTO(apiKey)
+	if dto.ExpiresAt.Valid && !dto.ExpiresAt.Time.After(time.Now().UTC()) {
+		return nil, "", apikey.ErrInvalidExpiry
+	}
+
+	key, prefix, err := generateKey()
+	if err != nil {
+		return nil, "", apikey.ErrRepository
+	}
+	dto.Prefix = prefix
+	dto.KeyHash = hashKey(key)
+
+	saved, err := s.repository.save(dto)
+	if err != nil {
+		return nil, "", apikey.ErrRepository
+	}
+
+	return saved.toProto(), key, nil
+}
+
+func (s basicService) List(ctx context.Context, companyID int64) ([]*pb.APIKey, error) {
+	if companyID == 0 {
+		return nil, apikey.ErrRequireCompany
+	}
+	if err := s.authorizeCompany(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	found, err := s.repository.findAllByCompany(companyID)
+	if err != nil {
+		return nil, apikey.ErrRepository
+	}
+
+	apiKeys := make([]*pb.APIKey, len(found))
+	for i, dto := range found {
+		apiKeys[i] = dto.toProto()
+	}
+	return apiKeys, nil
+}
+
+func (s basicService) Revoke(ctx context.Context, id int64) error {
+	if err := s.authenticated(ctx); err != nil {
+		return err
+	}
+	found, err := s.repository.find(id)
+	if err != nil {
+		return mapRepositoryError(err)
+	}
+	// keys belonging to other companies are reported as missing rather than
+	// forbidden so their existence isn't leaked
+	if s.authorizeCompany(ctx, found.CompanyID) != nil {
+		return apikey.ErrKeyNotFound
+	}
+	if found.RevokedAt.Valid {
+		return nil
+	}
+
+	return mapRepositoryError(s.repository.revoke(id))
+}
+
+func (s basicService) Authenticate(ctx context.Context, key string) (*pb.APIKey, error) {
+	if !strings.HasPrefix(key, keyScheme+"_") {
+		return nil, apikey.ErrInvalidKey
+	}
+
+	hash := hashKey(key)
+	found, err := s.repository.findByHash(hash)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, apikey.ErrInvalidKey
+		}
+		return nil, apikey.ErrRepository
+	}
+	if subtle.ConstantTimeCompare([]byte(found.KeyHash), []byte(hash)) != 1 {
+		return nil, apikey.ErrInvalidKey
+	}
+	if found.RevokedAt.Valid {
+		return nil, apikey.ErrRevokedKey
+	}
+	if found.ExpiresAt.Valid && !found.ExpiresAt.Time.After(time.Now().UTC()) {
+		return nil, apikey.ErrExpiredKey
+	}
+
+	if err := s.repository.touch(found.ID); err != nil {
+		return nil, apikey.ErrRepository
+	}
+
+	return found.toProto(), nil
+}
+
+// authenticated rejects unauthenticated callers, whether or not API keys are
+// required by the rest of the API, unless bootstrapping
+func (s basicService) authenticated(ctx context.Context) error {
+	if _, ok := auth.FromContext(ctx); !ok && !s.bootstrap {
+		return auth.ErrMissingCredentials
+	}
+	return nil
+}
+
+// authorizeCompany restricts API key callers to managing their own company's
+// keys with the appropriate scope
+func (s basicService) authorizeCompany(ctx context.Context, companyID int64) error {
+	if err := s.authenticated(ctx); err != nil {
+		return err
+	}
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if principal.CompanyID != companyID {
+		return auth.ErrInsufficientScope
+	}
+	return nil
+}
+
+func mapRepositoryError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case err == ErrNotFound:
+		return apikey.ErrKeyNotFound
+	default:
+		return apikey.ErrRepository
+	}
+}
+
+func generateKey() (key string, prefix string, err error) {
+	buf := make([]byte, keyPrefixBytes+keySecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	prefix = hex.EncodeToString(buf[:keyPrefixBytes])
+	secret := hex.EncodeToString(buf[keyPrefixBytes:])
+	return keyScheme + "_" + prefix + "_" + secret, prefix, nil
+}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+type apiKeyDTO struct {
+	ID         int64          `db:"id"`
+	CompanyID  int64          `db:"company_id"`
+	Name       string         `db:"name"`
+	Prefix     string         `db:"prefix"`
+	KeyHash    string         `db:"key_hash"`
+	Scopes     pq.StringArray `db:"scopes"`
+	ExpiresAt  pq.NullTime    `db:"expires_at"`
+	LastUsedAt pq.NullTime    `db:"last_used_at"`
+	RevokedAt  pq.NullTime    `db:"revoked_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+	UpdatedAt  time.Time      `db:"updated_at"`
+}
+
+func (apiKey *apiKeyDTO) toProto() *pb.APIKey {
+	return &pb.APIKey{
+		ID:         apiKey.ID,
+		CompanyID:  apiKey.CompanyID,
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     []string(apiKey.Scopes),
+		ExpiresAt:  genPbNullTimestamp(apiKey.ExpiresAt),
+		LastUsedAt: genPbNullTimestamp(apiKey.LastUsedAt),
+		RevokedAt:  genPbNullTimestamp(apiKey.RevokedAt),
+		CreatedAt:  genPbTimestamp(apiKey.CreatedAt),
+		UpdatedAt:  genPbTimestamp(apiKey.UpdatedAt),
+	}
+}
+
+func toDTO(apiKey *pb.APIKey) *apiKeyDTO {
+	scopes := pq.StringArray(apiKey.Scopes)
+	if scopes == nil {
+		scopes = pq.StringArray{}
+	}
+	return &apiKeyDTO{
+		ID:        apiKey.ID,
+		CompanyID: apiKey.CompanyID,
+		Name:      strings.TrimSpace(apiKey.Name),
+		Scopes:    scopes,
+		ExpiresAt: genDTONullTime(apiKey.ExpiresAt),
+	}
+}
+
+func genDTONullTime(pbTime *types.Timestamp) pq.NullTime {
+	if pbTime == nil {
+		return pq.NullTime{}
+	}
+	ts, err := types.TimestampFromProto(pbTime)
+	if err != nil {
+		return pq.NullTime{}
+	}
+	return pq.NullTime{Time: ts.UTC(), Valid: true}
+}
+
+func genPbNullTimestamp(nullTime pq.NullTime) *types.Timestamp {
+	if !nullTime.Valid {
+		return nil
+	}
+	return genPbTimestamp(nullTime.Time)
+}
+
+func genPbTimestamp(time time.Time) *types.Timestamp {
+	ts, err := types.TimestampProto(time)
+	if err != nil {
+		return &types.Timestamp{Seconds: 0, Nanos: 0}
+	}
+	return ts
+}
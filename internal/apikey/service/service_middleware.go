@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+
+	pb "github.com/nathanows/elegant-monolith/_protos/apikeys"
+	"github.com/nathanows/elegant-monolith/internal/apikey"
+	"github.com/nathanows/elegant-monolith/pkg/errreport"
+	"github.com/nathanows/elegant-monolith/pkg/logging"
+)
+
+// ServiceMiddleware describes a service middleware
+type ServiceMiddleware func(Service) Service
+
+// ServiceLoggingMiddleware takes a logger as a dependency and returns a service middleware
+func ServiceLoggingMiddleware(logger log.Logger) ServiceMiddleware {
+	return func(next Service) Service {
+		return serviceLoggingMiddleware{logger, next}
+	}
+}
+
+type serviceLoggingMiddleware struct {
+	logger log.Logger
+	next   Service
+}
+
+func (mw serviceLoggingMiddleware) Create(ctx context.Context, apiKey *pb.APIKey) (returned *pb.APIKey, key string, err error) {
+	defer func() {
+		if err == nil {
+			level.Info(mw.logger).Log("method", "Create", "id", returned.GetID(), "company_id", returned.GetCompanyID(), "prefix", returned.GetPrefix())
+		} else {
+			logging.ErrorLevel(mw.logger, err).Log("method", "Create", "err", err.Error())
+		}
+	}()
+	return mw.next.Create(ctx, apiKey)
+}
+
+func (mw serviceLoggingMiddleware) List(ctx context.Context, companyID int64) (returned []*pb.APIKey, err error) {
+	defer func() {
+		if err == nil {
+			level.Info(mw.logger).Log("method", "List", "company_id", companyID, "results_returned", len(returned))
+		} else {
+			logging.ErrorLevel(mw.logger, err).Log("method", "List", "company_id", companyID, "err", err.Error())
+		}
+	}()
+	return mw.next.List(ctx, companyID)
+}
+
+func (mw serviceLoggingMiddleware) Revoke(ctx context.Context, id int64) (err error) {
+	defer func() {
+		if err == nil {
+			level.Info(mw.logger).Log("method", "Revoke", "id", id)
+		} else {
+			logging.ErrorLevel(mw.logger, err).Log("method", "Revoke", "id", id, "err", err.Error())
+		}
+	}()
+	return mw.next.Revoke(ctx, id)
+}
+
+func (mw serviceLoggingMiddleware) Authenticate(ctx context.Context, key string) (returned *pb.APIKey, err error) {
+	defer func() {
+		if err != nil {
+			level.Warn(mw.logger).Log("method", "Authenticate", "err", err.Error())
+		}
+	}()
+	return mw.next.Authenticate(ctx, key)
+}
+
+// ServiceErrorReportingMiddleware takes a reporter as a dependency and returns
+// a service middleware reporting errors the service couldn't handle
+func ServiceErrorReportingMiddleware(reporter errreport.Reporter) ServiceMiddleware {
+	return func(next Service) Service {
+		return serviceErrorReportingMiddleware{reporter, next}
+	}
+}
+
+type serviceErrorReportingMiddleware struct {
+	reporter errreport.Reporter
+	next     Service
+}
+
+func (mw serviceErrorReportingMiddleware) Create(ctx context.Context, apiKey *pb.APIKey) (returned *pb.APIKey, key string, err error) {
+	defer func() { mw.report(ctx, "Create", err) }()
+	return mw.next.Create(ctx, apiKey)
+}
+
+func (mw serviceErrorReportingMiddleware) List(ctx context.Context, companyID int64) (returned []*pb.APIKey, err error) {
+	defer func() { mw.report(ctx, "List", err) }()
+	return mw.next.List(ctx, companyID)
+}
+
+func (mw serviceErrorReportingMiddleware) Revoke(ctx context.Context, id int64) (err error) {
+	defer func() { mw.report(ctx, "Revoke", err) }()
+	return mw.next.Revoke(ctx, id)
+}
+
+func (mw serviceErrorReportingMiddleware) Authenticate(ctx context.Context, key string) (returned *pb.APIKey, err error) {
+	defer func() { mw.report(ctx, "Authenticate", err) }()
+	return mw.next.Authenticate(ctx, key)
+}
+
+// report passes on unhandled errors and repository failures, the other
+// errors are the caller's to fix
+func (mw serviceErrorReportingMiddleware) report(ctx context.Context, method string, err error) {
+	event := errreport.Event{Err: err, Tags: map[string]string{"module": "apikey", "method": method}}
+	switch e := err.(type) {
+	case *errreport.UnhandledError:
+		event.Stack = e.Stack
+	default:
+		if err != apikey.ErrRepository {
+			return
+		}
+	}
+	mw.reporter.Report(ctx, event)
+}
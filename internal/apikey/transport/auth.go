@@ -0,0 +1,35 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/nathanows/elegant-monolith/internal/apikey"
+	"github.com/nathanows/elegant-monolith/internal/apikey/service"
+	"github.com/nathanows/elegant-monolith/pkg/auth"
+)
+
+// NewAuthenticator adapts the api key service for use by the auth middleware
+// guarding the other services' endpoints
+func NewAuthenticator(svc service.Service) auth.Authenticator {
+	return authenticator{svc}
+}
+
+type authenticator struct {
+	svc service.Service
+}
+
+func (a authenticator) Authenticate(ctx context.Context, key string) (*auth.Principal, error) {
+	apiKey, err := a.svc.Authenticate(ctx, key)
+	if err != nil {
+		if err == apikey.ErrRepository {
+			return nil, err
+		}
+		return nil, auth.ErrInvalidCredentials
+	}
+	return &auth.Principal{
+		Kind:      auth.KindAPIKey,
+		ID:        apiKey.ID,
+		CompanyID: apiKey.CompanyID,
+		Scopes:    apiKey.Scopes,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/gogo/protobuf/types"
+
+	pb "github.com/nathanows/elegant-monolith/_protos/apikeys"
+	"github.com/nathanows/elegant-monolith/internal/apikey"
+	"github.com/nathanows/elegant-monolith/internal/apikey/service"
+	"github.com/nathanows/elegant-monolith/pkg/auth"
+	"github.com/nathanows/elegant-monolith/pkg/ratelimit"
+	"github.com/nathanows/elegant-monolith/pkg/validation"
+)
+
+// Set collects all of the endpoints that compose an api key service. It's
+// meant to be used as a helper struct, to collect all of the endpoints into a
+// single parameter.
+type Set struct {
+	CreateEndpoint endpoint.Endpoint
+	ListEndpoint   endpoint.Endpoint
+	RevokeEndpoint endpoint.Endpoint
+}
+
+// NewEndpointSet returns a constructed Set for use to instantiate server
+func NewEndpointSet(svc service.Service, logger log.Logger, authMiddleware endpoint.Middleware, limiter *ratelimit.Limiter) Set {
+	var createEndpoint endpoint.Endpoint
+	{
+		createEndpoint = MakeCreateEndpoint(svc)
+		createEndpoint = validation.Middleware()(createEndpoint)
+		createEndpoint = auth.ScopeMiddleware(apikey.ScopeWrite)(createEndpoint)
+		createEndpoint = limiter.Middleware("apikey", "Create")(createEndpoint)
+		createEndpoint = authMiddleware(createEndpoint)
+		createEndpoint = limiter.IPMiddleware()(createEndpoint)
+		createEndpoint = limiter.ConcurrencyMiddleware()(createEndpoint)
+		createEndpoint = LoggingMiddleware(log.With(logger, "method", "Create"))(createEndpoint)
+	}
+	var listEndpoint endpoint.Endpoint
+	{
+		listEndpoint = MakeListEndpoint(svc)
+		listEndpoint = validation.Middleware()(listEndpoint)
+		listEndpoint = auth.ScopeMiddleware(apikey.ScopeRead)(listEndpoint)
+		listEndpoint = limiter.Middleware("apikey", "List")(listEndpoint)
+		listEndpoint = authMiddleware(listEndpoint)
+		listEndpoint = limiter.IPMiddleware()(listEndpoint)
+		listEndpoint = limiter.ConcurrencyMiddleware()(listEndpoint)
+		listEndpoint = LoggingMiddleware(log.With(logger, "method", "List"))(listEndpoint)
+	}
+	var revokeEndpoint endpoint.Endpoint
+	{
+		revokeEndpoint = MakeRevokeEndpoint(svc)
+		revokeEndpoint = validation.Middleware()(revokeEndpoint)
+		revokeEndpoint = auth.ScopeMiddleware(apikey.ScopeWrite)(revokeEndpoint)
+		revokeEndpoint = limiter.Middleware("apikey", "Revoke")(revokeEndpoint)
+		revokeEndpoint = authMiddleware(revokeEndpoint)
+		revokeEndpoint = limiter.IPMiddleware()(revokeEndpoint)
+		revokeEndpoint = limiter.ConcurrencyMiddleware()(revokeEndpoint)
+		revokeEndpoint = LoggingMiddleware(log.With(logger, "method", "Revoke"))(revokeEndpoint)
+	}
+	return Set{
+		CreateEndpoint: createEndpoint,
+		ListEndpoint:   listEndpoint,
+		RevokeEndpoint: revokeEndpoint,
+	}
+}
+
+// MakeCreateEndpoint constructs a Create endpoint wrapping the service.
+func MakeCreateEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*pb.CreateAPIKeyRequest)
+		if req.APIKey == nil {
+			return nil, apikey.ErrRequireCompany
+		}
+		apiKey, key, err := s.Create(ctx, req.APIKey)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.CreateAPIKeyResponse{APIKey: apiKey, Key: key}, nil
+	}
+}
+
+// MakeListEndpoint constructs a List endpoint wrapping the service.
+func MakeListEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*pb.ListAPIKeysRequest)
+		apiKeys, err := s.List(ctx, req.CompanyID)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.ListAPIKeysResponse{APIKeys: apiKeys}, nil
+	}
+}
+
+// MakeRevokeEndpoint constructs a Revoke endpoint wrapping the service.
+func MakeRevokeEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*pb.RevokeAPIKeyRequest)
+		if err := s.Revoke(ctx, req.ID); err != nil {
+			return nil, err
+		}
+		return &types.Empty{}, nil
+	}
+}
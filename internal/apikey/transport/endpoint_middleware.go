@@ -0,0 +1,31 @@
+package transport
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+
+	"github.com/nathanows/elegant-monolith/pkg/logging"
+)
+
+// LoggingMiddleware returns an endpoint middleware that logs the
+// duration of each invocation, and the resulting error, if any, along with
+// the request. Sensitive request fields are masked by the logger.
+func LoggingMiddleware(logger log.Logger) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+			defer func(begin time.Time) {
+				if err != nil {
+					logging.ErrorLevel(logger, err).Log("transport_error", err, "request", request, "took", time.Since(begin))
+				} else {
+					level.Info(logger).Log("took", time.Since(begin))
+				}
+			}(time.Now())
+			return next(ctx, request)
+
+		}
+	}
+}
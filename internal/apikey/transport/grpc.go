@@ -0,0 +1,77 @@
+package transport
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	grpctransport "github.com/go-kit/kit/transport/grpc"
+	types "github.com/gogo/protobuf/types"
+	oldcontext "golang.org/x/net/context"
+
+	pb "github.com/nathanows/elegant-monolith/_protos/apikeys"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+	"github.com/nathanows/elegant-monolith/pkg/auth"
+	"github.com/nathanows/elegant-monolith/pkg/ratelimit"
+)
+
+type grpcServer struct {
+	create grpctransport.Handler
+	list   grpctransport.Handler
+	revoke grpctransport.Handler
+}
+
+// NewGRPCServer makes a set of endpoints available as a gRPC APIKeySvcServer.
+func NewGRPCServer(endpoints Set, logger log.Logger) pb.APIKeySvcServer {
+	options := []grpctransport.ServerOption{
+		grpctransport.ServerErrorLogger(logger),
+		grpctransport.ServerBefore(auth.GRPCToContext(), ratelimit.GRPCToContext()),
+	}
+
+	return &grpcServer{
+		create: newGPRCServer(endpoints.CreateEndpoint, options...),
+		list:   newGPRCServer(endpoints.ListEndpoint, options...),
+		revoke: newGPRCServer(endpoints.RevokeEndpoint, options...),
+	}
+}
+
+func (s *grpcServer) Create(ctx oldcontext.Context, req *pb.CreateAPIKeyRequest) (*pb.CreateAPIKeyResponse, error) {
+	_, rep, err := s.create.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.CreateAPIKeyResponse), nil
+}
+
+func (s *grpcServer) List(ctx oldcontext.Context, req *pb.ListAPIKeysRequest) (*pb.ListAPIKeysResponse, error) {
+	_, rep, err := s.list.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.ListAPIKeysResponse), nil
+}
+
+func (s *grpcServer) Revoke(ctx oldcontext.Context, req *pb.RevokeAPIKeyRequest) (*types.Empty, error) {
+	_, rep, err := s.revoke.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*types.Empty), nil
+}
+
+func newGPRCServer(endpoint endpoint.Endpoint, options ...grpctransport.ServerOption) *grpctransport.Server {
+	return grpctransport.NewServer(
+		endpoint,
+		decodeGRPCRequest,
+		encodeGRPCResponse,
+		options...,
+	)
+}
+
+func decodeGRPCRequest(_ context.Context, grpcReq interface{}) (interface{}, error) {
+	return grpcReq, nil
+}
+
+func encodeGRPCResponse(_ context.Context, grpcResp interface{}) (interface{}, error) {
+	return grpcResp, nil
+}
@@ -1,21 +1,36 @@
 package company
 
-import "errors"
+import (
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+)
 
 // Company Service Error descriptions
 const (
-	ErrorInvalidName     = "invalid company name"
-	ErrorRequireName     = "missing required name"
+	ErrorRequireCompany  = "missing required company"
+	ErrorInvalidCompany  = "invalid company"
 	ErrorUniqueName      = "company with name already exists"
 	ErrorCompanyNotFound = "company not found"
+	ErrorNotDeleted      = "company is not deleted"
 	ErrorRepository      = "unable to query repository"
 )
 
-// Device Service Errors
+// Company Service Error reasons
+const (
+	ReasonRequireCompany  = "COMPANY_REQUIRED"
+	ReasonInvalidCompany  = "COMPANY_INVALID"
+	ReasonUniqueName      = "COMPANY_NAME_TAKEN"
+	ReasonCompanyNotFound = "COMPANY_NOT_FOUND"
+	ReasonNotDeleted      = "COMPANY_NOT_DELETED"
+	ReasonRepository      = "COMPANY_REPOSITORY_UNAVAILABLE"
+)
+
+// Company Service Errors
 var (
-	ErrInvalidName     = errors.New(ErrorInvalidName)
-	ErrRequireName     = errors.New(ErrorRequireName)
-	ErrUniqueName      = errors.New(ErrorUniqueName)
-	ErrCompanyNotFound = errors.New(ErrorCompanyNotFound)
-	ErrRepository      = errors.New(ErrorRepository)
+	ErrRequireCompany    = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonRequireCompany, ErrorRequireCompany)
+	ErrInvalidCompany    = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonInvalidCompany, ErrorInvalidCompany)
+	ErrUniqueName        = apierror.New(codespb.Code_ALREADY_EXISTS, ReasonUniqueName, ErrorUniqueName)
+	ErrCompanyNotFound   = apierror.New(codespb.Code_NOT_FOUND, ReasonCompanyNotFound, ErrorCompanyNotFound)
+	ErrCompanyNotDeleted = apierror.New(codespb.Code_FAILED_PRECONDITION, ReasonNotDeleted, ErrorNotDeleted)
+	ErrRepository        = apierror.New(codespb.Code_INTERNAL, ReasonRepository, ErrorRepository)
 )
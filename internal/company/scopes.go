@@ -0,0 +1,7 @@
+package company
+
+// Scopes granting API key access to the company service
+const (
+	ScopeRead  = "company:read"
+	ScopeWrite = "company:write"
+)
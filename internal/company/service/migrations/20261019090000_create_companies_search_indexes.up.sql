@@ -0,0 +1,4 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX companies_name_fts_idx ON companies USING gin (to_tsvector('simple', name));
+CREATE INDEX companies_name_trgm_idx ON companies USING gin (name gin_trgm_ops);
@@ -0,0 +1 @@
+ALTER TABLE companies ADD COLUMN version bigint NOT NULL DEFAULT 1;
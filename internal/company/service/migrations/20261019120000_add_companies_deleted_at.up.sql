@@ -0,0 +1,7 @@
+ALTER TABLE companies ADD COLUMN deleted_at timestamp without time zone;
+
+-- names of deleted companies can be taken again, Undelete fails while they are
+ALTER TABLE companies DROP CONSTRAINT companies_name_key;
+CREATE UNIQUE INDEX companies_name_key ON companies (name) WHERE deleted_at IS NULL;
+
+CREATE INDEX companies_deleted_at_idx ON companies (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -1,10 +1,16 @@
 package service
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+
+	"github.com/nathanows/elegant-monolith/pkg/listing"
 )
 
 // Common errors
@@ -12,14 +18,20 @@ var (
 	ErrRepository = errors.New("unable to handle request")
 	ErrNotFound   = errors.New("company not found")
 	ErrUniqueness = errors.New("uniqueness constraint violation")
+	ErrStale      = errors.New("company version mismatch")
+	ErrNotDeleted = errors.New("company not deleted")
 )
 
 // Repository is the datastore inteface for the company service
 type Repository interface {
-	save(*companyDTO) (*companyDTO, error)
+	save(company *companyDTO, fields []string) (*companyDTO, error)
 	delete(int64) error
-	find(int64) (*companyDTO, error)
-	findAll() ([]*companyDTO, error)
+	undelete(int64) (*companyDTO, error)
+	purge(retention time.Duration) (int64, error)
+	find(id int64, showDeleted bool) (*companyDTO, error)
+	findAll(q listing.Query, after []string, offset, limit int) ([]*companyDTO, error)
+	count(q listing.Query) (int64, error)
+	search(query, prefixes string, limit int) ([]*companySearchDTO, error)
 }
 
 type repository struct {
@@ -33,7 +45,11 @@ func NewRepository(db *sqlx.DB) Repository {
 	}
 }
 
-func (r repository) save(company *companyDTO) (*companyDTO, error) {
+// save inserts company when it has no ID, otherwise updates the given fields
+// of the stored company. A non zero Version is a precondition of the update,
+// which fails with ErrStale when the stored company is at another version,
+// and ErrNotFound without one when the company is gone.
+func (r repository) save(company *companyDTO, fields []string) (*companyDTO, error) {
 	var stmt *sqlx.NamedStmt
 	{
 		var err error
@@ -49,15 +65,33 @@ func (r repository) save(company *companyDTO) (*companyDTO, error) {
 				return nil, ErrNotFound
 			}
 
-			stmt, err = r.db.PrepareNamed(sqlUpdateCompany)
+			var sets []string
+			for _, field := range fields {
+				if column, ok := updatableColumns[field]; ok {
+					sets = append(sets, column+" = :"+column)
+				}
+			}
+			if len(sets) == 0 {
+				return nil, ErrRepository
+			}
+			stmt, err = r.db.PrepareNamed(fmt.Sprintf(sqlUpdateCompany, strings.Join(sets, ", ")))
 		}
 		if err != nil {
 			return nil, ErrRepository
 		}
 	}
+	defer stmt.Close()
 
 	var saved companyDTO
 	if err := stmt.QueryRowx(company).StructScan(&saved); err != nil {
+		if err == sql.ErrNoRows && company.ID != 0 {
+			// the company changed or was deleted since it was checked, only
+			// a precondition on its version makes that a conflict
+			if company.Version != 0 {
+				return nil, ErrStale
+			}
+			return nil, ErrNotFound
+		}
 		if pgerr, ok := err.(*pq.Error); ok {
 			if pgerr.Code == "23505" {
 				return nil, ErrUniqueness
@@ -69,26 +103,138 @@ func (r repository) save(company *companyDTO) (*companyDTO, error) {
 	return &saved, nil
 }
 
+// delete marks a company as deleted, it is purged once the retention period
+// is over
 func (r repository) delete(id int64) error {
+	res, err := r.db.Exec(sqlDeleteCompany, id)
+	if err != nil {
+		return ErrRepository
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return ErrRepository
+	}
+	if deleted == 0 {
+		return ErrNotFound
+	}
 	return nil
 }
 
-func (r repository) find(id int64) (*companyDTO, error) {
-	return &companyDTO{}, nil
+func (r repository) undelete(id int64) (*companyDTO, error) {
+	var restored companyDTO
+	if err := r.db.QueryRowx(sqlUndeleteCompany, id).StructScan(&restored); err != nil {
+		if err == sql.ErrNoRows {
+			if _, err := r.find(id, false); err == nil {
+				return nil, ErrNotDeleted
+			}
+			return nil, ErrNotFound
+		}
+		if pgerr, ok := err.(*pq.Error); ok && pgerr.Code == "23505" {
+			return nil, ErrUniqueness
+		}
+		return nil, ErrRepository
+	}
+	return &restored, nil
+}
+
+// purge permanently removes the companies deleted for longer than retention
+func (r repository) purge(retention time.Duration) (int64, error) {
+	res, err := r.db.Exec(sqlPurgeCompanies, retention.Seconds())
+	if err != nil {
+		return 0, ErrRepository
+	}
+	purged, err := res.RowsAffected()
+	if err != nil {
+		return 0, ErrRepository
+	}
+	return purged, nil
+}
+
+func (r repository) find(id int64, showDeleted bool) (*companyDTO, error) {
+	var company companyDTO
+	if err := r.db.Get(&company, sqlFindCompany, id, showDeleted); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, ErrRepository
+	}
+	return &company, nil
 }
 
-func (r repository) findAll() ([]*companyDTO, error) {
-	return []*companyDTO{}, nil
+func (r repository) findAll(q listing.Query, after []string, offset, limit int) ([]*companyDTO, error) {
+	stmt, args := q.Select(sqlSelectCompanies, after, offset, limit)
+	companies := []*companyDTO{}
+	if err := r.db.Select(&companies, r.db.Rebind(stmt), args...); err != nil {
+		return nil, ErrRepository
+	}
+	return companies, nil
 }
 
-const sqlCompanyExists = "select exists(select 1 from companies where id = $1)"
+func (r repository) count(q listing.Query) (int64, error) {
+	stmt, args := q.Count(sqlCountCompanies)
+	var count int64
+	if err := r.db.Get(&count, r.db.Rebind(stmt), args...); err != nil {
+		return 0, ErrRepository
+	}
+	return count, nil
+}
+
+func (r repository) search(query, prefixes string, limit int) ([]*companySearchDTO, error) {
+	results := []*companySearchDTO{}
+	if err := r.db.Select(&results, sqlSearchCompanies, query, prefixes, limit); err != nil {
+		return nil, ErrRepository
+	}
+	return results, nil
+}
+
+const sqlCompanyExists = "select exists(select 1 from companies where id = $1 and deleted_at is null)"
 
 const sqlInsertCompany = `
 	INSERT INTO companies (name)
 	VALUES (:name)
-	RETURNING id, name, created_at, updated_at;`
+	RETURNING id, name, created_at, updated_at, version, deleted_at;`
+
+// updatableColumns maps the fields an update may set to their column
+var updatableColumns = map[string]string{
+	"name": "name",
+}
 
+// sqlUpdateCompany is completed with the columns to set
 const sqlUpdateCompany = `
-	UPDATE companies SET name = :name
-	WHERE id = :id
-	RETURNING id, name, created_at, updated_at;`
+	UPDATE companies SET %s, version = version + 1
+	WHERE id = :id AND deleted_at IS NULL AND (:version = 0 OR version = :version)
+	RETURNING id, name, created_at, updated_at, version, deleted_at;`
+
+// sqlSelectCompanies is completed by listing.Query.Select
+const sqlSelectCompanies = "SELECT id, name, created_at, updated_at, version, deleted_at FROM companies"
+
+const sqlFindCompany = `
+	SELECT id, name, created_at, updated_at, version, deleted_at FROM companies
+	WHERE id = $1 AND ($2 OR deleted_at IS NULL);`
+
+const sqlDeleteCompany = `
+	UPDATE companies SET deleted_at = timezone('utc', now()), version = version + 1
+	WHERE id = $1 AND deleted_at IS NULL;`
+
+const sqlUndeleteCompany = `
+	UPDATE companies SET deleted_at = NULL, version = version + 1
+	WHERE id = $1 AND deleted_at IS NOT NULL
+	RETURNING id, name, created_at, updated_at, version, deleted_at;`
+
+const sqlPurgeCompanies = `
+	DELETE FROM companies
+	WHERE deleted_at < timezone('utc', now()) - $1 * interval '1 second';`
+
+const sqlCountCompanies = "select count(*) from companies"
+
+// sqlSearchCompanies matches names by full text search, on prefixes of the
+// words of the query ($2), and by trigram similarity to the query ($1) to
+// catch misspellings. Both are backed by indexes of the name.
+const sqlSearchCompanies = `
+	SELECT id, name, created_at, updated_at, version, deleted_at,
+		ts_rank(to_tsvector('simple', name), prefixes) + word_similarity($1, name) AS score,
+		ts_headline('simple', name, prefixes, 'StartSel=<em>, StopSel=</em>, HighlightAll=true') AS name_highlight
+	FROM companies, to_tsquery('simple', $2) prefixes
+	WHERE (to_tsvector('simple', name) @@ prefixes OR $1 <% name) AND deleted_at IS NULL
+	ORDER BY score DESC, id
+	LIMIT $3;`
@@ -2,109 +2,333 @@ package service
 
 import (
 	"context"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
-	"github.com/asaskevich/govalidator"
 	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
 	"github.com/gogo/protobuf/types"
 
 	pb "github.com/nathanows/elegant-monolith/_protos/companyusers"
 	"github.com/nathanows/elegant-monolith/internal/company"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+	"github.com/nathanows/elegant-monolith/pkg/errreport"
+	"github.com/nathanows/elegant-monolith/pkg/etag"
+	"github.com/nathanows/elegant-monolith/pkg/fieldmask"
+	"github.com/nathanows/elegant-monolith/pkg/listing"
+	"github.com/nathanows/elegant-monolith/pkg/pagination"
+	"github.com/nathanows/elegant-monolith/pkg/validation"
 )
 
 // Service interface defines the core Company service functionality
 type Service interface {
-	Save(ctx context.Context, company *pb.Company) (*pb.Company, error)
-	Find(ctx context.Context, id int64) (*pb.Company, error)
-	FindAll(ctx context.Context) ([]*pb.Company, error)
+	Save(ctx context.Context, company *pb.Company, mask *types.FieldMask) (*pb.Company, error)
+	Find(ctx context.Context, id int64, showDeleted bool) (*pb.Company, error)
+	FindAll(ctx context.Context, req *pb.FindAllCompaniesRequest) (*pb.FindAllCompaniesResponse, error)
 	Delete(ctx context.Context, id int64) error
+	Undelete(ctx context.Context, id int64) (*pb.Company, error)
+	Search(ctx context.Context, req *pb.SearchCompaniesRequest) (*pb.SearchCompaniesResponse, error)
 }
 
 // NewService returns an initialized Service wired up with all middleware
-func NewService(logger log.Logger, repository Repository) Service {
+func NewService(logger log.Logger, reporter errreport.Reporter, repository Repository, rules *validation.Registry, paging pagination.Config) Service {
 	var svc Service
 	{
-		svc = NewBasicService(repository)
+		svc = NewBasicService(repository, rules, paging)
+		svc = ServiceErrorReportingMiddleware(reporter)(svc)
 		svc = ServiceLoggingMiddleware(logger)(svc)
 	}
 	return svc
 }
 
 // NewBasicService returns an initialized Service without middleware
-func NewBasicService(repository Repository) Service {
+func NewBasicService(repository Repository, rules *validation.Registry, paging pagination.Config) Service {
 	return basicService{
 		repository: repository,
+		rules:      rules,
+		paging:     paging,
 	}
 }
 
 type basicService struct {
 	repository Repository
+	rules      *validation.Registry
+	paging     pagination.Config
 }
 
-func (s basicService) Save(ctx context.Context, companyToSave *pb.Company) (*pb.Company, error) {
-	companyDTO := toDTO(companyToSave)
+// companyMaskFields are the fields of a company an update mask may name
+var companyMaskFields = fieldmask.Fields{
+	Mutable:   []string{"name"},
+	Immutable: []string{"id", "created_at", "updated_at"},
+}
 
-	if ok, err := validate(companyDTO); !ok {
-		errs := err.(govalidator.Errors)
-		firstErr := errs.Errors()[0].(govalidator.Error)
-		if firstErr.Name == "Name" {
-			if firstErr.Validator == "required" {
-				return nil, company.ErrRequireName
-			}
-			return nil, company.ErrInvalidName
-		}
-		// TODO: need a way to monitor for unhandled errors
+// NewRules returns the validation rules of the company service, on top of
+// the ones declared in its proto messages
+func NewRules(config validation.Config) (*validation.Registry, error) {
+	rules, err := validation.NewRegistry(config)
+	if err != nil {
 		return nil, err
 	}
+	rules.Register("name", notDuck, rules.ReservedName(), rules.Denylist())
+	return rules, nil
+}
 
-	saved, err := s.repository.save(companyDTO)
+// notDuck is an example of a rule specific to a service
+var notDuck = validation.Rule{
+	Name:        "notduck",
+	Description: "is a duck, no ducks allowed",
+	Valid: func(value string) bool {
+		return strings.ToLower(value) != "duck"
+	},
+}
+
+func (s basicService) Save(ctx context.Context, companyToSave *pb.Company, mask *types.FieldMask) (*pb.Company, error) {
+	if companyToSave == nil {
+		return nil, company.ErrRequireCompany
+	}
+	if companyToSave.ID == 0 && len(mask.GetPaths()) > 0 {
+		return nil, fieldmask.ErrInvalidUpdateMask.WithFields(apierror.FieldViolation{Field: "update_mask", Description: "only applies to updates, company.id is not set"})
+	}
+	fields, err := companyMaskFields.Paths(mask)
 	if err != nil {
-		switch {
-		case err == ErrRepository:
-			return nil, company.ErrRepository
-		case err == ErrNotFound:
-			return nil, company.ErrCompanyNotFound
-		case err == ErrUniqueness:
-			return nil, company.ErrUniqueName
-		default:
-			// TODO: need a way to monitor for unhandled errors
-			return nil, err
+		return nil, err
+	}
+	companyDTO := toDTO(companyToSave)
+
+	// the etag of the company wins over an If-Match precondition
+	tag, field := companyToSave.Etag, "company.etag"
+	if tag == "" {
+		tag, field = etag.FromContext(ctx), etag.IfMatchHeader
+	}
+	if companyDTO.Version, err = etag.Parse(tag, field); err != nil {
+		return nil, err
+	}
+	if companyToSave.ID == 0 && companyDTO.Version != 0 {
+		return nil, etag.ErrInvalid.WithFields(apierror.FieldViolation{Field: field, Description: "only applies to updates, company.id is not set"})
+	}
+
+	var violations []apierror.FieldViolation
+	for _, violation := range s.rules.Check(companyToSave, "company") {
+		if fieldmask.Covers(fields, strings.TrimPrefix(violation.Field, "company.")) {
+			violations = append(violations, violation)
 		}
 	}
+	if len(violations) > 0 {
+		return nil, company.ErrInvalidCompany.WithFields(violations...)
+	}
+
+	saved, err := s.repository.save(companyDTO, fields)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
 
 	return saved.toProto(), nil
 }
 
-func (s basicService) Find(ctx context.Context, id int64) (*pb.Company, error) {
-	return &pb.Company{ID: 1}, nil
+func (s basicService) Find(ctx context.Context, id int64, showDeleted bool) (*pb.Company, error) {
+	found, err := s.repository.find(id, showDeleted)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	return found.toProto(), nil
 }
 
+// Delete marks a company as deleted, it can be restored with Undelete until
+// it is purged
 func (s basicService) Delete(ctx context.Context, id int64) error {
+	if err := s.repository.delete(id); err != nil {
+		return repositoryError(err)
+	}
 	return nil
 }
 
-func (s basicService) FindAll(ctx context.Context) ([]*pb.Company, error) {
-	return nil, nil
+func (s basicService) Undelete(ctx context.Context, id int64) (*pb.Company, error) {
+	restored, err := s.repository.undelete(id)
+	if err != nil {
+		return nil, repositoryError(err)
+	}
+	return restored.toProto(), nil
+}
+
+// repositoryError maps a repository error to the error of the company module
+func repositoryError(err error) error {
+	switch err {
+	case ErrRepository:
+		return company.ErrRepository
+	case ErrNotFound:
+		return company.ErrCompanyNotFound
+	case ErrNotDeleted:
+		return company.ErrCompanyNotDeleted
+	case ErrUniqueness:
+		return company.ErrUniqueName
+	case ErrStale:
+		return etag.ErrMismatch
+	default:
+		return errreport.Unhandled(err)
+	}
+}
+
+// PurgeDeleted permanently removes the companies deleted for longer than
+// retention, every hour until ctx is done
+func PurgeDeleted(ctx context.Context, repository Repository, retention time.Duration, logger log.Logger) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			purged, err := repository.purge(retention)
+			if err != nil {
+				level.Error(logger).Log("method", "PurgeDeleted", "err", err.Error())
+				continue
+			}
+			level.Info(logger).Log("method", "PurgeDeleted", "purged", purged)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// purgeInterval is how often PurgeDeleted runs
+const purgeInterval = time.Hour
+
+func (s basicService) FindAll(ctx context.Context, req *pb.FindAllCompaniesRequest) (*pb.FindAllCompaniesResponse, error) {
+	page := req.GetPagination()
+	requested := page.GetPageSize()
+	if requested == 0 {
+		requested = page.GetResultsPerPage()
+	}
+	size, err := s.paging.Size(requested)
+	if err != nil {
+		return nil, err
+	}
+	q, err := companyFields.Parse(req.GetFilter(), req.GetOrderBy())
+	if err != nil {
+		return nil, err
+	}
+	if !req.GetShowDeleted() {
+		q = q.And("deleted_at IS NULL")
+	}
+	var (
+		after  listing.Cursor
+		number int32 = 1
+	)
+	if page.GetPageToken() != "" {
+		if err := pagination.Decode(page.GetPageToken(), &after); err != nil {
+			return nil, err
+		}
+		if !q.Valid(after) {
+			return nil, pagination.ErrInvalidPageToken
+		}
+	} else if page.GetPageNumber() > 1 {
+		number = page.GetPageNumber()
+	}
+
+	// one more than the page size tells whether there's a next page
+	found, err := s.repository.findAll(q, after.Keys, int(number-1)*size, size+1)
+	if err != nil {
+		return nil, company.ErrRepository
+	}
+
+	resp := &pb.FindAllCompaniesResponse{Companies: make([]*pb.Company, 0, size)}
+	if page.GetPageToken() == "" {
+		resp.Pagination = &pb.Pagination{PageNumber: number, ResultsPerPage: int32(size)}
+	}
+	if len(found) > size {
+		found = found[:size]
+		resp.NextPageToken = pagination.Token(q.Cursor(found[size-1].key))
+	}
+	for _, c := range found {
+		resp.Companies = append(resp.Companies, c.toProto())
+	}
+
+	if page.GetIncludeTotalCount() {
+		if resp.TotalCount, err = s.repository.count(q); err != nil {
+			return nil, company.ErrRepository
+		}
+	}
+	return resp, nil
 }
 
-func validate(company *companyDTO) (bool, error) {
-	return govalidator.ValidateStruct(company)
+// companyFields are the fields companies can be filtered and ordered by
+var companyFields = listing.Fields{
+	"id":         {Column: "id", Type: listing.Int},
+	"name":       {Column: "name", Type: listing.String},
+	"created_at": {Column: "created_at", Type: listing.Timestamp},
+	"updated_at": {Column: "updated_at", Type: listing.Timestamp},
 }
 
-func init() {
-	govalidator.TagMap["notduck"] = govalidator.Validator(verifyNotDuck)
+func (s basicService) Search(ctx context.Context, req *pb.SearchCompaniesRequest) (*pb.SearchCompaniesResponse, error) {
+	if req.GetPageSize() < 0 {
+		return nil, pagination.ErrInvalidPageSize.WithFields(apierror.FieldViolation{Field: "page_size", Description: "must not be negative"})
+	}
+	size, err := s.paging.Size(req.GetPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.SearchCompaniesResponse{Results: []*pb.CompanySearchResult{}}
+	prefixes := prefixQuery(req.GetQuery())
+	if prefixes == "" {
+		return resp, nil
+	}
+	found, err := s.repository.search(req.GetQuery(), prefixes, size)
+	if err != nil {
+		return nil, company.ErrRepository
+	}
+	for _, c := range found {
+		resp.Results = append(resp.Results, &pb.CompanySearchResult{
+			Company:    c.toProto(),
+			Score:      c.Score,
+			Highlights: []*pb.Highlight{{Field: "name", Snippet: c.NameHighlight}},
+		})
+	}
+	return resp, nil
 }
 
-func verifyNotDuck(str string) bool {
-	return strings.ToLower(str) != "duck"
+// prefixQuery turns the words of a search query into a tsquery matching
+// names containing words starting with each of them, e.g. "acme co" into
+// "acme:* & co:*". Anything but letters and digits separates words, so the
+// result is always a valid tsquery.
+func prefixQuery(query string) string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
 }
 
 type companyDTO struct {
-	ID        int64     `db:"id"`
-	Name      string    `db:"name" valid:"notduck~No ducks allowed,required"` // example custom validator
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID        int64      `db:"id"`
+	Name      string     `db:"name"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+	Version   int64      `db:"version"`
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+// key returns the value of one of companyFields, for page tokens
+func (company *companyDTO) key(field string) string {
+	switch field {
+	case "id":
+		return strconv.FormatInt(company.ID, 10)
+	case "name":
+		return company.Name
+	case "created_at":
+		return company.CreatedAt.Format(time.RFC3339Nano)
+	case "updated_at":
+		return company.UpdatedAt.Format(time.RFC3339Nano)
+	}
+	return ""
+}
+
+type companySearchDTO struct {
+	companyDTO
+	Score         float64 `db:"score"`
+	NameHighlight string  `db:"name_highlight"`
 }
 
 func (company *companyDTO) toProto() *pb.Company {
@@ -113,6 +337,8 @@ func (company *companyDTO) toProto() *pb.Company {
 		Name:      company.Name,
 		CreatedAt: genPbTimestamp(company.CreatedAt),
 		UpdatedAt: genPbTimestamp(company.UpdatedAt),
+		Etag:      etag.Format(company.Version),
+		DeletedAt: genPbDeletedAt(company.DeletedAt),
 	}
 }
 
@@ -133,6 +359,13 @@ func genDTOTimestamp(pbTime *types.Timestamp) time.Time {
 	return ts
 }
 
+func genPbDeletedAt(deletedAt *time.Time) *types.Timestamp {
+	if deletedAt == nil {
+		return nil
+	}
+	return genPbTimestamp(*deletedAt)
+}
+
 func genPbTimestamp(time time.Time) *types.Timestamp {
 	ts, err := types.TimestampProto(time)
 	if err != nil {
@@ -2,9 +2,16 @@ package service
 
 import (
 	"context"
+	"strings"
 
 	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/gogo/protobuf/types"
+
 	pb "github.com/nathanows/elegant-monolith/_protos/companyusers"
+	"github.com/nathanows/elegant-monolith/internal/company"
+	"github.com/nathanows/elegant-monolith/pkg/errreport"
+	"github.com/nathanows/elegant-monolith/pkg/logging"
 )
 
 // ServiceMiddleware describes a service middleware
@@ -22,34 +29,126 @@ type serviceLoggingMiddleware struct {
 	next   Service
 }
 
-func (mw serviceLoggingMiddleware) Save(ctx context.Context, company *pb.Company) (returned *pb.Company, err error) {
+func (mw serviceLoggingMiddleware) Save(ctx context.Context, company *pb.Company, mask *types.FieldMask) (returned *pb.Company, err error) {
 	defer func() {
 		if err == nil {
-			mw.logger.Log("method", "Save", "id", returned.ID)
+			level.Info(mw.logger).Log("method", "Save", "id", returned.GetID(), "update_mask", strings.Join(mask.GetPaths(), ","))
 		} else {
-			mw.logger.Log("method", "Save", "err", err.Error())
+			logging.ErrorLevel(mw.logger, err).Log("method", "Save", "err", err.Error())
 		}
 	}()
-	return mw.next.Save(ctx, company)
+	return mw.next.Save(ctx, company, mask)
 }
 
-func (mw serviceLoggingMiddleware) Find(ctx context.Context, id int64) (*pb.Company, error) {
+func (mw serviceLoggingMiddleware) Find(ctx context.Context, id int64, showDeleted bool) (returned *pb.Company, err error) {
 	defer func() {
-		mw.logger.Log("method", "Find", "id", id)
+		if err == nil {
+			level.Info(mw.logger).Log("method", "Find", "id", id, "show_deleted", showDeleted)
+		} else {
+			logging.ErrorLevel(mw.logger, err).Log("method", "Find", "id", id, "show_deleted", showDeleted, "err", err.Error())
+		}
 	}()
-	return mw.next.Find(ctx, id)
+	return mw.next.Find(ctx, id, showDeleted)
 }
 
-func (mw serviceLoggingMiddleware) Delete(ctx context.Context, id int64) error {
+func (mw serviceLoggingMiddleware) Delete(ctx context.Context, id int64) (err error) {
 	defer func() {
-		mw.logger.Log("method", "Delete", "id", id)
+		if err == nil {
+			level.Info(mw.logger).Log("method", "Delete", "id", id)
+		} else {
+			logging.ErrorLevel(mw.logger, err).Log("method", "Delete", "id", id, "err", err.Error())
+		}
 	}()
 	return mw.next.Delete(ctx, id)
 }
 
-func (mw serviceLoggingMiddleware) FindAll(ctx context.Context) (returned []*pb.Company, err error) {
+func (mw serviceLoggingMiddleware) Undelete(ctx context.Context, id int64) (returned *pb.Company, err error) {
+	defer func() {
+		if err == nil {
+			level.Info(mw.logger).Log("method", "Undelete", "id", id)
+		} else {
+			logging.ErrorLevel(mw.logger, err).Log("method", "Undelete", "id", id, "err", err.Error())
+		}
+	}()
+	return mw.next.Undelete(ctx, id)
+}
+
+func (mw serviceLoggingMiddleware) FindAll(ctx context.Context, req *pb.FindAllCompaniesRequest) (returned *pb.FindAllCompaniesResponse, err error) {
+	defer func() {
+		if err == nil {
+			level.Info(mw.logger).Log("method", "FindAll", "results_returned", len(returned.GetCompanies()), "more", returned.GetNextPageToken() != "")
+		} else {
+			logging.ErrorLevel(mw.logger, err).Log("method", "FindAll", "err", err.Error())
+		}
+	}()
+	return mw.next.FindAll(ctx, req)
+}
+
+func (mw serviceLoggingMiddleware) Search(ctx context.Context, req *pb.SearchCompaniesRequest) (returned *pb.SearchCompaniesResponse, err error) {
 	defer func() {
-		mw.logger.Log("method", "FindAll", "results_returned", len(returned))
+		if err == nil {
+			level.Info(mw.logger).Log("method", "Search", "results_returned", len(returned.GetResults()))
+		} else {
+			logging.ErrorLevel(mw.logger, err).Log("method", "Search", "err", err.Error())
+		}
 	}()
-	return mw.next.FindAll(ctx)
+	return mw.next.Search(ctx, req)
+}
+
+// ServiceErrorReportingMiddleware takes a reporter as a dependency and returns
+// a service middleware reporting errors the service couldn't handle
+func ServiceErrorReportingMiddleware(reporter errreport.Reporter) ServiceMiddleware {
+	return func(next Service) Service {
+		return serviceErrorReportingMiddleware{reporter, next}
+	}
+}
+
+type serviceErrorReportingMiddleware struct {
+	reporter errreport.Reporter
+	next     Service
+}
+
+func (mw serviceErrorReportingMiddleware) Save(ctx context.Context, company *pb.Company, mask *types.FieldMask) (returned *pb.Company, err error) {
+	defer func() { mw.report(ctx, "Save", err) }()
+	return mw.next.Save(ctx, company, mask)
+}
+
+func (mw serviceErrorReportingMiddleware) Find(ctx context.Context, id int64, showDeleted bool) (returned *pb.Company, err error) {
+	defer func() { mw.report(ctx, "Find", err) }()
+	return mw.next.Find(ctx, id, showDeleted)
+}
+
+func (mw serviceErrorReportingMiddleware) Delete(ctx context.Context, id int64) (err error) {
+	defer func() { mw.report(ctx, "Delete", err) }()
+	return mw.next.Delete(ctx, id)
+}
+
+func (mw serviceErrorReportingMiddleware) Undelete(ctx context.Context, id int64) (returned *pb.Company, err error) {
+	defer func() { mw.report(ctx, "Undelete", err) }()
+	return mw.next.Undelete(ctx, id)
+}
+
+func (mw serviceErrorReportingMiddleware) FindAll(ctx context.Context, req *pb.FindAllCompaniesRequest) (returned *pb.FindAllCompaniesResponse, err error) {
+	defer func() { mw.report(ctx, "FindAll", err) }()
+	return mw.next.FindAll(ctx, req)
+}
+
+func (mw serviceErrorReportingMiddleware) Search(ctx context.Context, req *pb.SearchCompaniesRequest) (returned *pb.SearchCompaniesResponse, err error) {
+	defer func() { mw.report(ctx, "Search", err) }()
+	return mw.next.Search(ctx, req)
+}
+
+// report passes on unhandled errors and repository failures, the other
+// errors are the caller's to fix
+func (mw serviceErrorReportingMiddleware) report(ctx context.Context, method string, err error) {
+	event := errreport.Event{Err: err, Tags: map[string]string{"module": "company", "method": method}}
+	switch e := err.(type) {
+	case *errreport.UnhandledError:
+		event.Stack = e.Stack
+	default:
+		if err != company.ErrRepository {
+			return
+		}
+	}
+	mw.reporter.Report(ctx, event)
 }
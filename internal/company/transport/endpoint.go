@@ -5,57 +5,137 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/log"
+	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 
 	pb "github.com/nathanows/elegant-monolith/_protos/companyusers"
+	"github.com/nathanows/elegant-monolith/internal/company"
 	"github.com/nathanows/elegant-monolith/internal/company/service"
+	"github.com/nathanows/elegant-monolith/pkg/auth"
+	"github.com/nathanows/elegant-monolith/pkg/idempotency"
+	"github.com/nathanows/elegant-monolith/pkg/ratelimit"
+	"github.com/nathanows/elegant-monolith/pkg/validation"
 )
 
 // Set collects all of the endpoints that compose a user service. It's meant to
 // be used as a helper struct, to collect all of the endpoints into a single
 // parameter.
 type Set struct {
-	SaveEndpoint    endpoint.Endpoint
-	FindEndpoint    endpoint.Endpoint
-	DeleteEndpoint  endpoint.Endpoint
-	FindAllEndpoint endpoint.Endpoint
+	SaveEndpoint     endpoint.Endpoint
+	FindEndpoint     endpoint.Endpoint
+	DeleteEndpoint   endpoint.Endpoint
+	UndeleteEndpoint endpoint.Endpoint
+	FindAllEndpoint  endpoint.Endpoint
+	SearchEndpoint   endpoint.Endpoint
 }
 
 // NewEndpointSet returns a constructed Set for use to instantiate server
-func NewEndpointSet(svc service.Service, logger log.Logger) Set {
+func NewEndpointSet(svc service.Service, logger log.Logger, authMiddleware endpoint.Middleware, limiter *ratelimit.Limiter, keys *idempotency.Keys) Set {
 	var saveEndpoint endpoint.Endpoint
 	{
 		saveEndpoint = MakeSaveEndpoint(svc)
+		saveEndpoint = validation.Middleware()(saveEndpoint)
+		saveEndpoint = keys.Middleware("company", "Save", func() proto.Message { return &pb.Company{} })(saveEndpoint)
+		saveEndpoint = auth.CompanyMiddleware(companyOf)(saveEndpoint)
+		saveEndpoint = auth.ScopeMiddleware(company.ScopeWrite)(saveEndpoint)
+		saveEndpoint = limiter.Middleware("company", "Save")(saveEndpoint)
+		saveEndpoint = authMiddleware(saveEndpoint)
+		saveEndpoint = limiter.IPMiddleware()(saveEndpoint)
+		saveEndpoint = limiter.ConcurrencyMiddleware()(saveEndpoint)
 		saveEndpoint = LoggingMiddleware(log.With(logger, "method", "Save"))(saveEndpoint)
 	}
 	var findEndpoint endpoint.Endpoint
 	{
 		findEndpoint = MakeFindEndpoint(svc)
+		findEndpoint = validation.Middleware()(findEndpoint)
+		findEndpoint = auth.CompanyMiddleware(companyOf)(findEndpoint)
+		findEndpoint = auth.ScopeMiddleware(company.ScopeRead)(findEndpoint)
+		findEndpoint = limiter.Middleware("company", "Find")(findEndpoint)
+		findEndpoint = authMiddleware(findEndpoint)
+		findEndpoint = limiter.IPMiddleware()(findEndpoint)
+		findEndpoint = limiter.ConcurrencyMiddleware()(findEndpoint)
 		findEndpoint = LoggingMiddleware(log.With(logger, "method", "Find"))(findEndpoint)
 	}
 	var deleteEndpoint endpoint.Endpoint
 	{
 		deleteEndpoint = MakeDeleteEndpoint(svc)
+		deleteEndpoint = validation.Middleware()(deleteEndpoint)
+		deleteEndpoint = auth.CompanyMiddleware(companyOf)(deleteEndpoint)
+		deleteEndpoint = auth.ScopeMiddleware(company.ScopeWrite)(deleteEndpoint)
+		deleteEndpoint = limiter.Middleware("company", "Delete")(deleteEndpoint)
+		deleteEndpoint = authMiddleware(deleteEndpoint)
+		deleteEndpoint = limiter.IPMiddleware()(deleteEndpoint)
+		deleteEndpoint = limiter.ConcurrencyMiddleware()(deleteEndpoint)
 		deleteEndpoint = LoggingMiddleware(log.With(logger, "method", "Delete"))(deleteEndpoint)
 	}
+	var undeleteEndpoint endpoint.Endpoint
+	{
+		undeleteEndpoint = MakeUndeleteEndpoint(svc)
+		undeleteEndpoint = validation.Middleware()(undeleteEndpoint)
+		undeleteEndpoint = auth.CompanyMiddleware(companyOf)(undeleteEndpoint)
+		undeleteEndpoint = auth.ScopeMiddleware(company.ScopeWrite)(undeleteEndpoint)
+		undeleteEndpoint = limiter.Middleware("company", "Undelete")(undeleteEndpoint)
+		undeleteEndpoint = authMiddleware(undeleteEndpoint)
+		undeleteEndpoint = limiter.IPMiddleware()(undeleteEndpoint)
+		undeleteEndpoint = limiter.ConcurrencyMiddleware()(undeleteEndpoint)
+		undeleteEndpoint = LoggingMiddleware(log.With(logger, "method", "Undelete"))(undeleteEndpoint)
+	}
 	var findAllEndpoint endpoint.Endpoint
 	{
 		findAllEndpoint = MakeFindAllEndpoint(svc)
+		findAllEndpoint = validation.Middleware()(findAllEndpoint)
+		findAllEndpoint = auth.CompanyMiddleware(companyOf)(findAllEndpoint)
+		findAllEndpoint = auth.ScopeMiddleware(company.ScopeRead)(findAllEndpoint)
+		findAllEndpoint = limiter.Middleware("company", "FindAll")(findAllEndpoint)
+		findAllEndpoint = authMiddleware(findAllEndpoint)
+		findAllEndpoint = limiter.IPMiddleware()(findAllEndpoint)
+		findAllEndpoint = limiter.ConcurrencyMiddleware()(findAllEndpoint)
 		findAllEndpoint = LoggingMiddleware(log.With(logger, "method", "FindAll"))(findAllEndpoint)
 	}
+	var searchEndpoint endpoint.Endpoint
+	{
+		searchEndpoint = MakeSearchEndpoint(svc)
+		searchEndpoint = validation.Middleware()(searchEndpoint)
+		searchEndpoint = auth.CompanyMiddleware(companyOf)(searchEndpoint)
+		searchEndpoint = auth.ScopeMiddleware(company.ScopeRead)(searchEndpoint)
+		searchEndpoint = limiter.Middleware("company", "Search")(searchEndpoint)
+		searchEndpoint = authMiddleware(searchEndpoint)
+		searchEndpoint = limiter.IPMiddleware()(searchEndpoint)
+		searchEndpoint = limiter.ConcurrencyMiddleware()(searchEndpoint)
+		searchEndpoint = LoggingMiddleware(log.With(logger, "method", "Search"))(searchEndpoint)
+	}
 	return Set{
-		SaveEndpoint:    saveEndpoint,
-		FindEndpoint:    findEndpoint,
-		DeleteEndpoint:  deleteEndpoint,
-		FindAllEndpoint: findAllEndpoint,
+		SaveEndpoint:     saveEndpoint,
+		FindEndpoint:     findEndpoint,
+		DeleteEndpoint:   deleteEndpoint,
+		UndeleteEndpoint: undeleteEndpoint,
+		FindAllEndpoint:  findAllEndpoint,
+		SearchEndpoint:   searchEndpoint,
 	}
 }
 
+// companyOf returns the company a request acts on. Listings and searches
+// span every company and new companies have none yet, API keys being
+// refused them.
+func companyOf(request interface{}) (int64, bool) {
+	switch req := request.(type) {
+	case *pb.SaveCompanyRequest:
+		return req.GetCompany().GetID(), req.GetCompany().GetID() != 0
+	case *pb.FindCompanyRequest:
+		return req.ID, true
+	case *pb.DeleteCompanyRequest:
+		return req.ID, true
+	case *pb.UndeleteCompanyRequest:
+		return req.ID, true
+	}
+	return 0, false
+}
+
 // MakeSaveEndpoint constructs a Save endpoint wrapping the service.
 func MakeSaveEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(*pb.SaveCompanyRequest)
-		company, err := s.Save(ctx, req.Company)
+		company, err := s.Save(ctx, req.Company, req.UpdateMask)
 		if err != nil {
 			return nil, err
 		}
@@ -67,7 +147,10 @@ func MakeSaveEndpoint(s service.Service) endpoint.Endpoint {
 func MakeFindEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(*pb.FindCompanyRequest)
-		company, err := s.Find(ctx, req.ID)
+		company, err := s.Find(ctx, req.ID, req.ShowDeleted)
+		if err != nil {
+			return nil, err
+		}
 		return company, nil
 	}
 }
@@ -76,15 +159,45 @@ func MakeFindEndpoint(s service.Service) endpoint.Endpoint {
 func MakeDeleteEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 		req := request.(*pb.DeleteCompanyRequest)
-		err = s.Delete(ctx, req.ID)
+		if err := s.Delete(ctx, req.ID); err != nil {
+			return nil, err
+		}
 		return &types.Empty{}, nil
 	}
 }
 
+// MakeUndeleteEndpoint constructs an Undelete endpoint wrapping the service.
+func MakeUndeleteEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*pb.UndeleteCompanyRequest)
+		company, err := s.Undelete(ctx, req.ID)
+		if err != nil {
+			return nil, err
+		}
+		return company, nil
+	}
+}
+
 // MakeFindAllEndpoint constructs a FindAll endpoint wrapping the service.
 func MakeFindAllEndpoint(s service.Service) endpoint.Endpoint {
 	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
-		companies, err := s.FindAll(ctx)
-		return &pb.FindAllCompaniesResponse{Companies: companies}, nil
+		req := request.(*pb.FindAllCompaniesRequest)
+		resp, err := s.FindAll(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+}
+
+// MakeSearchEndpoint constructs a Search endpoint wrapping the service.
+func MakeSearchEndpoint(s service.Service) endpoint.Endpoint {
+	return func(ctx context.Context, request interface{}) (response interface{}, err error) {
+		req := request.(*pb.SearchCompaniesRequest)
+		resp, err := s.Search(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return resp, nil
 	}
 }
@@ -6,18 +6,22 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+
+	"github.com/nathanows/elegant-monolith/pkg/logging"
 )
 
 // LoggingMiddleware returns an endpoint middleware that logs the
-// duration of each invocation, and the resulting error, if any.
+// duration of each invocation, and the resulting error, if any, along with
+// the request. Sensitive request fields are masked by the logger.
 func LoggingMiddleware(logger log.Logger) endpoint.Middleware {
 	return func(next endpoint.Endpoint) endpoint.Endpoint {
 		return func(ctx context.Context, request interface{}) (response interface{}, err error) {
 			defer func(begin time.Time) {
 				if err != nil {
-					logger.Log("transport_error", err, "took", time.Since(begin))
+					logging.ErrorLevel(logger, err).Log("transport_error", err, "request", request, "took", time.Since(begin))
 				} else {
-					logger.Log("took", time.Since(begin))
+					level.Info(logger).Log("took", time.Since(begin))
 				}
 			}(time.Now())
 			return next(ctx, request)
@@ -0,0 +1,130 @@
+package transport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/gogo/protobuf/types"
+
+	pb "github.com/nathanows/elegant-monolith/_protos/companyusers"
+	"github.com/nathanows/elegant-monolith/internal/company"
+	"github.com/nathanows/elegant-monolith/pkg/auth"
+	"github.com/nathanows/elegant-monolith/pkg/idempotency"
+	"github.com/nathanows/elegant-monolith/pkg/ratelimit"
+)
+
+// echoService answers every call as if it succeeded
+type echoService struct{}
+
+func (echoService) Save(_ context.Context, c *pb.Company, _ *types.FieldMask) (*pb.Company, error) {
+	return c, nil
+}
+
+func (echoService) Find(_ context.Context, id int64, _ bool) (*pb.Company, error) {
+	return &pb.Company{ID: id}, nil
+}
+
+func (echoService) FindAll(context.Context, *pb.FindAllCompaniesRequest) (*pb.FindAllCompaniesResponse, error) {
+	return &pb.FindAllCompaniesResponse{}, nil
+}
+
+func (echoService) Delete(context.Context, int64) error {
+	return nil
+}
+
+func (echoService) Undelete(_ context.Context, id int64) (*pb.Company, error) {
+	return &pb.Company{ID: id}, nil
+}
+
+func (echoService) Search(context.Context, *pb.SearchCompaniesRequest) (*pb.SearchCompaniesResponse, error) {
+	return &pb.SearchCompaniesResponse{}, nil
+}
+
+// authenticateAs returns an auth middleware authenticating every request as
+// principal, or none when nil
+func authenticateAs(principal *auth.Principal) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			if principal != nil {
+				ctx = auth.NewContext(ctx, principal)
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+func TestEndpointSetCompanyScoping(t *testing.T) {
+	keyOfA := &auth.Principal{Kind: auth.KindAPIKey, ID: 1, CompanyID: 1, Scopes: []string{company.ScopeRead, company.ScopeWrite}}
+	set := func(principal *auth.Principal) Set {
+		keys := idempotency.NewKeys(nil, idempotency.Config{}, log.NewNopLogger())
+		return NewEndpointSet(echoService{}, log.NewNopLogger(), authenticateAs(principal), ratelimit.NewLimiter(ratelimit.Config{}), keys)
+	}
+	calls := []struct {
+		name     string
+		endpoint func(Set) endpoint.Endpoint
+		request  func(id int64) interface{}
+		// listing spans every company, an API key may never call it
+		listing bool
+	}{
+		{
+			name:     "Save",
+			endpoint: func(s Set) endpoint.Endpoint { return s.SaveEndpoint },
+			request: func(id int64) interface{} {
+				return &pb.SaveCompanyRequest{Company: &pb.Company{ID: id, Name: "acme"}}
+			},
+		},
+		{
+			name:     "Find",
+			endpoint: func(s Set) endpoint.Endpoint { return s.FindEndpoint },
+			request:  func(id int64) interface{} { return &pb.FindCompanyRequest{ID: id} },
+		},
+		{
+			name:     "Delete",
+			endpoint: func(s Set) endpoint.Endpoint { return s.DeleteEndpoint },
+			request:  func(id int64) interface{} { return &pb.DeleteCompanyRequest{ID: id} },
+		},
+		{
+			name:     "Undelete",
+			endpoint: func(s Set) endpoint.Endpoint { return s.UndeleteEndpoint },
+			request:  func(id int64) interface{} { return &pb.UndeleteCompanyRequest{ID: id} },
+		},
+		{
+			name:     "FindAll",
+			endpoint: func(s Set) endpoint.Endpoint { return s.FindAllEndpoint },
+			request:  func(int64) interface{} { return &pb.FindAllCompaniesRequest{} },
+			listing:  true,
+		},
+		{
+			name:     "Search",
+			endpoint: func(s Set) endpoint.Endpoint { return s.SearchEndpoint },
+			request:  func(int64) interface{} { return &pb.SearchCompaniesRequest{Query: "acme"} },
+			listing:  true,
+		},
+	}
+	for _, c := range calls {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := c.endpoint(set(keyOfA))(context.Background(), c.request(1))
+			if c.listing {
+				if err != auth.ErrOtherCompany {
+					t.Errorf("API key call error = %v, want %v", err, auth.ErrOtherCompany)
+				}
+			} else if err != nil {
+				t.Errorf("API key call on its own company failed: %v", err)
+			}
+
+			if _, err := c.endpoint(set(keyOfA))(context.Background(), c.request(2)); err != auth.ErrOtherCompany {
+				t.Errorf("API key call on another company error = %v, want %v", err, auth.ErrOtherCompany)
+			}
+			if _, err := c.endpoint(set(nil))(context.Background(), c.request(2)); err != nil {
+				t.Errorf("unauthenticated call failed: %v", err)
+			}
+		})
+	}
+
+	create := &pb.SaveCompanyRequest{Company: &pb.Company{Name: "acme"}}
+	if _, err := set(keyOfA).SaveEndpoint(context.Background(), create); err != auth.ErrOtherCompany {
+		t.Errorf("API key creating a company error = %v, want %v", err, auth.ErrOtherCompany)
+	}
+}
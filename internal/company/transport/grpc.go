@@ -8,38 +8,45 @@ import (
 	grpctransport "github.com/go-kit/kit/transport/grpc"
 	types "github.com/gogo/protobuf/types"
 	oldcontext "golang.org/x/net/context"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 
 	pb "github.com/nathanows/elegant-monolith/_protos/companyusers"
-	"github.com/nathanows/elegant-monolith/internal/company"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+	"github.com/nathanows/elegant-monolith/pkg/auth"
+	"github.com/nathanows/elegant-monolith/pkg/etag"
+	"github.com/nathanows/elegant-monolith/pkg/idempotency"
+	"github.com/nathanows/elegant-monolith/pkg/ratelimit"
 )
 
 type grpcServer struct {
-	save    grpctransport.Handler
-	find    grpctransport.Handler
-	delete  grpctransport.Handler
-	findAll grpctransport.Handler
+	save     grpctransport.Handler
+	find     grpctransport.Handler
+	delete   grpctransport.Handler
+	undelete grpctransport.Handler
+	findAll  grpctransport.Handler
+	search   grpctransport.Handler
 }
 
 // NewGRPCServer makes a set of endpoints available as a gRPC AddServer.
 func NewGRPCServer(endpoints Set, logger log.Logger) pb.CompanySvcServer {
 	options := []grpctransport.ServerOption{
 		grpctransport.ServerErrorLogger(logger),
+		grpctransport.ServerBefore(auth.GRPCToContext(), ratelimit.GRPCToContext(), etag.GRPCToContext(), idempotency.GRPCToContext()),
 	}
 
 	return &grpcServer{
-		save:    newGPRCServer(endpoints.SaveEndpoint, options...),
-		find:    newGPRCServer(endpoints.FindEndpoint, options...),
-		delete:  newGPRCServer(endpoints.DeleteEndpoint, options...),
-		findAll: newGPRCServer(endpoints.FindAllEndpoint, options...),
+		save:     newGPRCServer(endpoints.SaveEndpoint, options...),
+		find:     newGPRCServer(endpoints.FindEndpoint, options...),
+		delete:   newGPRCServer(endpoints.DeleteEndpoint, options...),
+		undelete: newGPRCServer(endpoints.UndeleteEndpoint, options...),
+		findAll:  newGPRCServer(endpoints.FindAllEndpoint, options...),
+		search:   newGPRCServer(endpoints.SearchEndpoint, options...),
 	}
 }
 
 func (s *grpcServer) Save(ctx oldcontext.Context, req *pb.SaveCompanyRequest) (*pb.Company, error) {
 	_, rep, err := s.save.ServeGRPC(ctx, req)
 	if err != nil {
-		encodedErr := encodeError(err)
+		encodedErr := apierror.GRPCError(err)
 		return nil, encodedErr
 	}
 	return rep.(*pb.Company), nil
@@ -48,7 +55,7 @@ func (s *grpcServer) Save(ctx oldcontext.Context, req *pb.SaveCompanyRequest) (*
 func (s *grpcServer) Find(ctx oldcontext.Context, req *pb.FindCompanyRequest) (*pb.Company, error) {
 	_, rep, err := s.find.ServeGRPC(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, apierror.GRPCError(err)
 	}
 	return rep.(*pb.Company), nil
 }
@@ -56,30 +63,33 @@ func (s *grpcServer) Find(ctx oldcontext.Context, req *pb.FindCompanyRequest) (*
 func (s *grpcServer) Delete(ctx oldcontext.Context, req *pb.DeleteCompanyRequest) (*types.Empty, error) {
 	_, rep, err := s.delete.ServeGRPC(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, apierror.GRPCError(err)
 	}
 	return rep.(*types.Empty), nil
 }
 
+func (s *grpcServer) Undelete(ctx oldcontext.Context, req *pb.UndeleteCompanyRequest) (*pb.Company, error) {
+	_, rep, err := s.undelete.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
+	}
+	return rep.(*pb.Company), nil
+}
+
 func (s *grpcServer) FindAll(ctx oldcontext.Context, req *pb.FindAllCompaniesRequest) (*pb.FindAllCompaniesResponse, error) {
 	_, rep, err := s.findAll.ServeGRPC(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, apierror.GRPCError(err)
 	}
 	return rep.(*pb.FindAllCompaniesResponse), nil
 }
 
-func encodeError(err error) error {
-	switch err {
-	case company.ErrRepository:
-		return status.Error(codes.Internal, err.Error())
-	case company.ErrRequireName, company.ErrInvalidName:
-		return status.Error(codes.InvalidArgument, err.Error())
-	case company.ErrUniqueName, company.ErrCompanyNotFound:
-		return status.Error(codes.FailedPrecondition, err.Error())
-	default:
-		return status.Error(codes.Internal, err.Error())
+func (s *grpcServer) Search(ctx oldcontext.Context, req *pb.SearchCompaniesRequest) (*pb.SearchCompaniesResponse, error) {
+	_, rep, err := s.search.ServeGRPC(ctx, req)
+	if err != nil {
+		return nil, apierror.GRPCError(err)
 	}
+	return rep.(*pb.SearchCompaniesResponse), nil
 }
 
 func newGPRCServer(endpoint endpoint.Endpoint, options ...grpctransport.ServerOption) *grpctransport.Server {
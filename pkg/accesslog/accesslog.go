@@ -0,0 +1,135 @@
+package accesslog
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/nathanows/elegant-monolith/pkg/requestid"
+)
+
+type contextKey int
+
+const routeContextKey contextKey = iota
+
+// SetRoute records the template of the route that matched the request, e.g.
+// /company/{id}, for the access log. Handlers deeper in the chain call it
+// since only they know which route matched.
+func SetRoute(ctx context.Context, route string) {
+	if holder, ok := ctx.Value(routeContextKey).(*string); ok {
+		*holder = route
+	}
+}
+
+// HTTPMiddleware returns a middleware logging one line per request once the
+// response is written, whether or not it reached an endpoint. Server errors
+// are logged at error level, everything else at info.
+func HTTPMiddleware(logger log.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			begin := time.Now()
+			route := new(string)
+			rw := &responseWriter{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rw, r.WithContext(context.WithValue(r.Context(), routeContextKey, route)))
+
+			l := level.Info(logger)
+			if rw.status >= http.StatusInternalServerError {
+				l = level.Error(logger)
+			}
+			l.Log(
+				"transport", "HTTP",
+				"method", r.Method,
+				"route", *route,
+				"path", r.URL.Path,
+				"status", rw.status,
+				"bytes", rw.bytes,
+				"took", time.Since(begin),
+				"user_agent", r.UserAgent(),
+				"remote_addr", r.RemoteAddr,
+				"request_id", requestid.FromContext(r.Context()),
+			)
+		})
+	}
+}
+
+// UnaryServerInterceptor is the gRPC equivalent of HTTPMiddleware, logging the
+// method, resulting code and latency of every call
+func UnaryServerInterceptor(logger log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		begin := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		l := level.Info(logger)
+		if isServerError(code) {
+			l = level.Error(logger)
+		}
+		var remoteAddr string
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			remoteAddr = p.Addr.String()
+		}
+		l.Log(
+			"transport", "gRPC",
+			"method", info.FullMethod,
+			"code", code.String(),
+			"took", time.Since(begin),
+			"remote_addr", remoteAddr,
+			"request_id", requestid.FromContext(ctx),
+		)
+		return resp, err
+	}
+}
+
+// isServerError mirrors the 5xx HTTP statuses
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.Unimplemented, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// responseWriter records the status and size of the response. It passes
+// flushes through for streamed gRPC-Web responses.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(b)
+	w.bytes += n
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *responseWriter) CloseNotify() <-chan bool {
+	if notifier, ok := w.ResponseWriter.(http.CloseNotifier); ok {
+		return notifier.CloseNotify()
+	}
+	return make(chan bool)
+}
@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/nathanows/elegant-monolith/pkg/buildinfo"
+	"github.com/nathanows/elegant-monolith/pkg/conf"
+)
+
+// Module describes one of the modules making up the monolith
+type Module struct {
+	Name     string   `json:"name"`
+	Services []string `json:"services"`
+}
+
+// Migration is a row of the schema_migrations table maintained by migrate
+type Migration struct {
+	Version int64 `db:"version" json:"version"`
+	Dirty   bool  `db:"dirty" json:"dirty"`
+}
+
+// NewHandler returns the operational endpoints: pprof and expvar under
+// /debug/, build info, the effective config with secrets redacted, applied
+// migrations and registered modules. They expose internals and must not be
+// served on the public listener.
+func NewHandler(config interface{}, db *sqlx.DB, modules []Module) http.Handler {
+	m := http.NewServeMux()
+
+	m.HandleFunc("/debug/pprof/", pprof.Index)
+	m.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	m.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	m.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	m.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	m.Handle("/debug/vars", expvar.Handler())
+
+	m.HandleFunc("/buildinfo", func(w http.ResponseWriter, r *http.Request) {
+		encode(w, http.StatusOK, buildinfo.Get())
+	})
+	m.HandleFunc("/config", func(w http.ResponseWriter, r *http.Request) {
+		encode(w, http.StatusOK, conf.Redacted(config))
+	})
+	m.HandleFunc("/migrations", func(w http.ResponseWriter, r *http.Request) {
+		migrations := []Migration{}
+		if err := db.SelectContext(r.Context(), &migrations, "SELECT version, dirty FROM schema_migrations ORDER BY version"); err != nil {
+			encode(w, http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
+			return
+		}
+		encode(w, http.StatusOK, migrations)
+	})
+	m.HandleFunc("/modules", func(w http.ResponseWriter, r *http.Request) {
+		encode(w, http.StatusOK, modules)
+	})
+
+	return m
+}
+
+func encode(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
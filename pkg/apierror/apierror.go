@@ -0,0 +1,212 @@
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	golangproto "github.com/golang/protobuf/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+)
+
+func init() {
+	// status details are unpacked through the golang/protobuf registry, which
+	// doesn't know about gogo generated messages
+	golangproto.RegisterType((*codespb.ErrorInfo)(nil), "core.codes.ErrorInfo")
+}
+
+// ReasonInternal is the reason of errors the code doesn't describe itself
+const ReasonInternal = "INTERNAL"
+
+// ErrInternal replaces unexpected errors so their details don't leak to
+// callers
+var ErrInternal = New(codespb.Code_INTERNAL, ReasonInternal, "internal error")
+
+// FieldViolation describes why a single request field is invalid
+type FieldViolation struct {
+	// Field is a dot separated path to the field, e.g. company.name
+	Field string `json:"field"`
+	// Rule names the validation rule the field broke, e.g. required
+	Rule        string `json:"rule,omitempty"`
+	Description string `json:"description"`
+}
+
+// Error is the error type shared by every module. It is mapped once to a gRPC
+// status and once to an HTTP status, by its Code.
+type Error struct {
+	Code codespb.Code
+	// Reason is a stable UPPER_SNAKE_CASE identifier clients may branch on,
+	// e.g. COMPANY_NAME_TAKEN
+	Reason  string
+	Message string
+	Fields  []FieldViolation
+}
+
+// New returns an Error
+func New(code codespb.Code, reason, message string) *Error {
+	return &Error{Code: code, Reason: reason, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// WithFields returns a copy of e describing the given invalid fields
+func (e *Error) WithFields(fields ...FieldViolation) *Error {
+	copied := *e
+	copied.Fields = append(append([]FieldViolation(nil), e.Fields...), fields...)
+	return &copied
+}
+
+// GRPCStatus maps the error to a gRPC status carrying an ErrorInfo with its
+// reason and, when fields are set, BadRequest details. The rules broken by
+// the fields go in the ErrorInfo, BadRequest has no room for them. grpc-go
+// calls it to encode errors returned by handlers.
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(codes.Code(e.Code), e.Message)
+	info := &codespb.ErrorInfo{Reason: e.Reason}
+	details := []golangproto.Message{info}
+	if len(e.Fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range e.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Description,
+			})
+			if field.Rule == "" {
+				continue
+			}
+			if info.FieldRules == nil {
+				info.FieldRules = map[string]string{}
+			}
+			if rules := info.FieldRules[field.Field]; rules != "" {
+				info.FieldRules[field.Field] = rules + "," + field.Rule
+			} else {
+				info.FieldRules[field.Field] = field.Rule
+			}
+		}
+		details = append(details, badRequest)
+	}
+	if detailed, err := st.WithDetails(details...); err == nil {
+		return detailed
+	}
+	return st
+}
+
+// HTTPStatus maps the error to an HTTP status
+func (e *Error) HTTPStatus() int {
+	return HTTPStatus(e.Code)
+}
+
+// HTTPBody is the JSON representation of an Error in HTTP responses, field
+// violations are listed under errors
+type HTTPBody struct {
+	Error  string           `json:"error"`
+	Code   string           `json:"code"`
+	Reason string           `json:"reason"`
+	Errors []FieldViolation `json:"errors,omitempty"`
+}
+
+// HTTPBody returns the JSON representation of the error
+func (e *Error) HTTPBody() HTTPBody {
+	return HTTPBody{Error: e.Message, Code: e.Code.String(), Reason: e.Reason, Errors: e.Fields}
+}
+
+// WriteHTTP writes the error as the JSON error body shared by every HTTP
+// response, with the given status, usually HTTPStatus
+func (e *Error) WriteHTTP(w http.ResponseWriter, status int) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(e.HTTPBody())
+}
+
+// GRPCError returns err in a form grpc-go encodes as a status: errors
+// describing their own status as they are, anything else as ErrInternal
+func GRPCError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return err
+	}
+	return ErrInternal
+}
+
+// FromError returns err as an Error, converting gRPC statuses and replacing
+// anything else with ErrInternal
+func FromError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	if e, ok := err.(*Error); ok {
+		return e
+	}
+	if st, ok := status.FromError(err); ok {
+		return FromStatus(st)
+	}
+	return ErrInternal
+}
+
+// FromStatus converts a gRPC status, reading the reason and fields back from
+// its details
+func FromStatus(st *status.Status) *Error {
+	e := New(codespb.Code(st.Code()), "", st.Message())
+	var rules map[string]string
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *codespb.ErrorInfo:
+			e.Reason = d.GetReason()
+			rules = d.GetFieldRules()
+		case *errdetails.BadRequest:
+			for _, violation := range d.GetFieldViolations() {
+				e.Fields = append(e.Fields, FieldViolation{Field: violation.GetField(), Description: violation.GetDescription()})
+			}
+		}
+	}
+	// a field breaking several rules is listed once per rule, in order
+	seen := map[string]int{}
+	for i, field := range e.Fields {
+		if fieldRules := strings.Split(rules[field.Field], ","); seen[field.Field] < len(fieldRules) {
+			e.Fields[i].Rule = fieldRules[seen[field.Field]]
+			seen[field.Field]++
+		}
+	}
+	if e.Reason == "" {
+		e.Reason = e.Code.String()
+	}
+	return e
+}
+
+// HTTPStatus maps a code to the HTTP status documented in code.proto
+func HTTPStatus(code codespb.Code) int {
+	switch code {
+	case codespb.Code_OK:
+		return http.StatusOK
+	case codespb.Code_CANCELLED:
+		return 499
+	case codespb.Code_INVALID_ARGUMENT, codespb.Code_FAILED_PRECONDITION, codespb.Code_OUT_OF_RANGE:
+		return http.StatusBadRequest
+	case codespb.Code_DEADLINE_EXCEEDED:
+		return http.StatusGatewayTimeout
+	case codespb.Code_NOT_FOUND:
+		return http.StatusNotFound
+	case codespb.Code_ALREADY_EXISTS, codespb.Code_ABORTED:
+		return http.StatusConflict
+	case codespb.Code_PERMISSION_DENIED:
+		return http.StatusForbidden
+	case codespb.Code_UNAUTHENTICATED:
+		return http.StatusUnauthorized
+	case codespb.Code_RESOURCE_EXHAUSTED:
+		return http.StatusTooManyRequests
+	case codespb.Code_UNIMPLEMENTED:
+		return http.StatusNotImplemented
+	case codespb.Code_UNAVAILABLE:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
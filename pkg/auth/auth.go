@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/go-kit/kit/endpoint"
+	"google.golang.org/grpc/metadata"
+
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+)
+
+// APIKeyHeader is the HTTP header machine clients pass their API key in
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyMetadata is the gRPC metadata equivalent of APIKeyHeader
+const APIKeyMetadata = "x-api-key"
+
+// Principal kinds
+const (
+	KindAPIKey = "api_key"
+	KindUser   = "user"
+)
+
+// Authentication error reasons
+const (
+	ReasonMissingCredentials = "CREDENTIALS_MISSING"
+	ReasonInvalidCredentials = "CREDENTIALS_INVALID"
+	ReasonInsufficientScope  = "SCOPE_INSUFFICIENT"
+	ReasonOtherCompany       = "COMPANY_NOT_GRANTED"
+)
+
+// Authentication errors
+var (
+	ErrMissingCredentials = apierror.New(codespb.Code_UNAUTHENTICATED, ReasonMissingCredentials, "missing credentials")
+	ErrInvalidCredentials = apierror.New(codespb.Code_UNAUTHENTICATED, ReasonInvalidCredentials, "invalid credentials")
+	ErrInsufficientScope  = apierror.New(codespb.Code_PERMISSION_DENIED, ReasonInsufficientScope, "insufficient scope")
+	ErrOtherCompany       = apierror.New(codespb.Code_PERMISSION_DENIED, ReasonOtherCompany, "api key not issued for this company")
+)
+
+// Principal describes the authenticated caller of a request
+type Principal struct {
+	Kind      string
+	ID        int64
+	CompanyID int64
+	Scopes    []string
+}
+
+// HasScope reports whether the principal has been granted the given scope
+func (p *Principal) HasScope(scope string) bool {
+	for _, s := range p.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Authenticator resolves a raw API key to the principal it was issued to
+type Authenticator interface {
+	Authenticate(ctx context.Context, key string) (*Principal, error)
+}
+
+type contextKey int
+
+const (
+	apiKeyContextKey contextKey = iota
+	principalContextKey
+)
+
+// NewContext returns a copy of ctx carrying the given principal
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey, principal)
+}
+
+// FromContext returns the authenticated principal, if any
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey).(*Principal)
+	return principal, ok
+}
+
+// GRPCToContext moves the API key from the request metadata into the context.
+// Meant to be used as a go-kit grpctransport.ServerBefore option.
+func GRPCToContext() func(context.Context, metadata.MD) context.Context {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		if keys := md.Get(APIKeyMetadata); len(keys) > 0 && keys[0] != "" {
+			return context.WithValue(ctx, apiKeyContextKey, keys[0])
+		}
+		return ctx
+	}
+}
+
+// Middleware returns an endpoint middleware that authenticates the API key
+// placed in the context by GRPCToContext. Requests without a
+// key are passed through unauthenticated unless required is set.
+func Middleware(authenticator Authenticator, required bool) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			key, ok := ctx.Value(apiKeyContextKey).(string)
+			if !ok {
+				if required {
+					return nil, ErrMissingCredentials
+				}
+				return next(ctx, request)
+			}
+
+			principal, err := authenticator.Authenticate(ctx, key)
+			if err != nil {
+				return nil, err
+			}
+
+			return next(NewContext(ctx, principal), request)
+		}
+	}
+}
+
+// ScopeMiddleware returns an endpoint middleware that rejects authenticated
+// callers lacking the given scope. Unauthenticated requests are left to
+// Middleware to allow or reject.
+func ScopeMiddleware(scope string) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			if principal, ok := FromContext(ctx); ok && !principal.HasScope(scope) {
+				return nil, ErrInsufficientScope
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// CompanyMiddleware returns an endpoint middleware that rejects API key
+// callers acting on a company other than the one their key was issued for.
+// companyID returns the company a request acts on, false when it acts on
+// several companies or on none yet, which API keys are never allowed to.
+// Other callers are left to Middleware and ScopeMiddleware.
+func CompanyMiddleware(companyID func(request interface{}) (int64, bool)) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			if principal, ok := FromContext(ctx); ok && principal.Kind == KindAPIKey {
+				if id, ok := companyID(request); !ok || id != principal.CompanyID {
+					return nil, ErrOtherCompany
+				}
+			}
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCompanyMiddleware(t *testing.T) {
+	// requests are the company they act on, zero for none
+	companyID := func(request interface{}) (int64, bool) {
+		id := request.(int64)
+		return id, id != 0
+	}
+	ok := func(context.Context, interface{}) (interface{}, error) { return nil, nil }
+	key := &Principal{Kind: KindAPIKey, ID: 1, CompanyID: 1}
+	user := &Principal{Kind: KindUser, ID: 1}
+
+	tests := []struct {
+		name      string
+		principal *Principal
+		company   int64
+		err       error
+	}{
+		{"API key on its company", key, 1, nil},
+		{"API key on another company", key, 2, ErrOtherCompany},
+		{"API key on no company", key, 0, ErrOtherCompany},
+		{"user", user, 2, nil},
+		{"unauthenticated", nil, 2, nil},
+	}
+	for _, tt := range tests {
+		ctx := context.Background()
+		if tt.principal != nil {
+			ctx = NewContext(ctx, tt.principal)
+		}
+		if _, err := CompanyMiddleware(companyID)(ok)(ctx, tt.company); err != tt.err {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package buildinfo
+
+import "runtime"
+
+// Injected at build time, e.g.
+//
+//	go build -ldflags "-X github.com/nathanows/elegant-monolith/pkg/buildinfo.Version=v1.2.0 \
+//	  -X github.com/nathanows/elegant-monolith/pkg/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X github.com/nathanows/elegant-monolith/pkg/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// Info describes the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"buildTime"`
+	GoVersion string `json:"goVersion"`
+}
+
+// Get returns the build info of the running binary
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+}
@@ -128,10 +128,28 @@ func recursivelySet(val reflect.Value, prefix string) error {
 			// you can only set with an int64 -> int
 			configVal := int64(viper.GetInt(tag))
 			thisField.SetInt(configVal)
+		case reflect.Float32:
+			fallthrough
+		case reflect.Float64:
+			thisField.SetFloat(viper.GetFloat64(tag))
 		case reflect.String:
 			thisField.SetString(viper.GetString(tag))
 		case reflect.Bool:
 			thisField.SetBool(viper.GetBool(tag))
+		case reflect.Slice:
+			if thisField.Type().Elem().Kind() == reflect.String {
+				thisField.Set(reflect.ValueOf(viper.GetStringSlice(tag)))
+				break
+			}
+			if err := viper.UnmarshalKey(tag, thisField.Addr().Interface()); err != nil {
+				return err
+			}
+		case reflect.Map:
+			// maps are keyed by config and can't be walked field by field,
+			// hand the whole subtree to viper to decode
+			if err := viper.UnmarshalKey(tag, thisField.Addr().Interface()); err != nil {
+				return err
+			}
 		default:
 			return fmt.Errorf("unexpected type detected ~ aborting: %s", thisField.Kind())
 		}
@@ -153,3 +171,44 @@ func getTag(field reflect.StructField) string {
 
 	return field.Name
 }
+
+// redactedTag marks config fields holding secrets, e.g. `secret:"true"`
+const redactedTag = "secret"
+
+// Redacted returns the config as a tree of maps keyed like the config file,
+// fit for display, with the values of fields tagged `secret:"true"` masked
+func Redacted(config interface{}) interface{} {
+	return redact(reflect.ValueOf(config))
+}
+
+func redact(val reflect.Value) interface{} {
+	val = reflect.Indirect(val)
+	if val.Kind() != reflect.Struct {
+		return val.Interface()
+	}
+
+	vType := val.Type()
+	out := make(map[string]interface{}, val.NumField())
+	for i := 0; i < val.NumField(); i++ {
+		thisField := val.Field(i)
+		thisType := vType.Field(i)
+		if thisType.PkgPath != "" {
+			continue
+		}
+
+		if thisType.Tag.Get(redactedTag) == "true" {
+			if !isZero(thisField) {
+				out[getTag(thisType)] = "[REDACTED]"
+			} else {
+				out[getTag(thisType)] = ""
+			}
+			continue
+		}
+		out[getTag(thisType)] = redact(thisField)
+	}
+	return out
+}
+
+func isZero(val reflect.Value) bool {
+	return reflect.DeepEqual(val.Interface(), reflect.Zero(val.Type()).Interface())
+}
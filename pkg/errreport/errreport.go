@@ -0,0 +1,123 @@
+package errreport
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/go-kit/kit/log"
+)
+
+// Reporter types accepted by Config
+const (
+	TypeNone   = "none"
+	TypeStdout = "stdout"
+	TypeFile   = "file"
+	TypeSentry = "sentry"
+)
+
+// Config selects where unhandled errors are reported
+type Config struct {
+	// Type is none, the default, stdout, file or sentry
+	Type string
+	// Path is the file events are appended to, for the file type
+	Path string
+	// DSN locates the Sentry project, e.g. http://key@localhost:9000/1 for a
+	// local stand-in, for the sentry type
+	DSN string `secret:"true"`
+	// Environment tags events, e.g. staging or production
+	Environment string
+}
+
+// Event is an error worth a human's attention, typically one the code doesn't
+// know how to handle or a recovered panic
+type Event struct {
+	Err error
+	// Stack is where the error was raised, as returned by Callers. Reporters
+	// capture their caller's stack when it's empty.
+	Stack []uintptr
+	// Tags narrow down where the error happened, e.g. module and method
+	Tags map[string]string
+}
+
+// Reporter ships events to wherever errors are monitored. Report must not
+// block the caller on delivery.
+type Reporter interface {
+	Report(ctx context.Context, event Event)
+}
+
+// New returns the Reporter selected by config, logging delivery failures to
+// logger
+func New(config Config, logger log.Logger) (Reporter, error) {
+	switch strings.ToLower(config.Type) {
+	case "", TypeNone:
+		return NopReporter(), nil
+	case TypeStdout:
+		return NewWriterReporter(os.Stdout, config.Environment), nil
+	case TypeFile:
+		if config.Path == "" {
+			return nil, fmt.Errorf("errreport: file reporter requires a path")
+		}
+		f, err := os.OpenFile(config.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("errreport: %s", err)
+		}
+		return NewWriterReporter(f, config.Environment), nil
+	case TypeSentry:
+		return NewSentryReporter(config.DSN, config.Environment, logger)
+	default:
+		return nil, fmt.Errorf("errreport: unknown reporter type %q", config.Type)
+	}
+}
+
+// NopReporter returns a Reporter discarding every event
+func NopReporter() Reporter {
+	return nopReporter{}
+}
+
+type nopReporter struct{}
+
+func (nopReporter) Report(context.Context, Event) {}
+
+// Callers returns the stack of the calling goroutine, skipping skip frames
+// on top of Callers itself
+func Callers(skip int) []uintptr {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip+2, pcs)
+	return pcs[:n]
+}
+
+// UnhandledError marks an error the code doesn't know how to handle. It
+// carries the stack it was raised from so the service middleware can report
+// it, and otherwise reads as the wrapped error.
+type UnhandledError struct {
+	Err   error
+	Stack []uintptr
+}
+
+// Unhandled wraps err, recording the caller's stack
+func Unhandled(err error) error {
+	return &UnhandledError{Err: err, Stack: Callers(1)}
+}
+
+func (e *UnhandledError) Error() string {
+	return e.Err.Error()
+}
+
+// stack returns the event's stack, falling back to the reporter's caller
+func (e Event) stack(skip int) []uintptr {
+	if len(e.Stack) > 0 {
+		return e.Stack
+	}
+	return Callers(skip + 1)
+}
+
+// cause unwraps UnhandledError, whose type says nothing about the error
+func (e Event) cause() error {
+	if unhandled, ok := e.Err.(*UnhandledError); ok {
+		return unhandled.Err
+	}
+	return e.Err
+}
@@ -0,0 +1,222 @@
+package errreport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+
+	"github.com/nathanows/elegant-monolith/pkg/buildinfo"
+	"github.com/nathanows/elegant-monolith/pkg/requestid"
+)
+
+const (
+	sentryClient    = "elegant-monolith/1.0"
+	sentryQueueSize = 100
+	// inAppPrefix marks the frames of this repository as application code
+	inAppPrefix = "github.com/nathanows/elegant-monolith/"
+)
+
+// NewSentryReporter returns a Reporter sending events to the store endpoint
+// of the Sentry project located by dsn, of the form
+// scheme://public_key@host[:port][/path]/project_id, events of a Sentry
+// served under a path going to /path/api/project_id/store/. Anything speaking the Sentry
+// protocol will do, including a local stand-in. Events are sent in the
+// background and dropped when the queue is full.
+func NewSentryReporter(dsn, environment string, logger log.Logger) (Reporter, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("errreport: invalid dsn: %s", err)
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	slash := strings.LastIndex(path, "/")
+	project := path[slash+1:]
+	if u.User == nil || u.User.Username() == "" || project == "" {
+		return nil, fmt.Errorf("errreport: invalid dsn, expected scheme://public_key@host[/path]/project_id")
+	}
+	prefix := path[:slash+1]
+
+	r := &sentryReporter{
+		storeURL:    fmt.Sprintf("%s://%s%sapi/%s/store/", u.Scheme, u.Host, prefix, project),
+		auth:        fmt.Sprintf("Sentry sentry_version=7, sentry_client=%s, sentry_key=%s", sentryClient, u.User.Username()),
+		environment: environment,
+		client:      &http.Client{Timeout: 5 * time.Second},
+		queue:       make(chan *sentryEvent, sentryQueueSize),
+		logger:      logger,
+	}
+	r.serverName, _ = os.Hostname()
+	if secret, ok := u.User.Password(); ok {
+		r.auth += ", sentry_secret=" + secret
+	}
+	go r.send()
+	return r, nil
+}
+
+type sentryReporter struct {
+	storeURL    string
+	auth        string
+	environment string
+	serverName  string
+	client      *http.Client
+	queue       chan *sentryEvent
+	logger      log.Logger
+}
+
+type sentryEvent struct {
+	EventID     string                 `json:"event_id"`
+	Timestamp   string                 `json:"timestamp"`
+	Level       string                 `json:"level"`
+	Platform    string                 `json:"platform"`
+	Logger      string                 `json:"logger"`
+	ServerName  string                 `json:"server_name,omitempty"`
+	Release     string                 `json:"release,omitempty"`
+	Environment string                 `json:"environment,omitempty"`
+	Message     string                 `json:"message"`
+	Exception   sentryExceptions       `json:"exception"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+}
+
+type sentryExceptions struct {
+	Values []sentryException `json:"values"`
+}
+
+type sentryException struct {
+	Type       string           `json:"type"`
+	Value      string           `json:"value"`
+	Stacktrace sentryStacktrace `json:"stacktrace"`
+}
+
+type sentryStacktrace struct {
+	Frames []sentryFrame `json:"frames"`
+}
+
+type sentryFrame struct {
+	Function string `json:"function"`
+	Module   string `json:"module"`
+	Filename string `json:"filename"`
+	AbsPath  string `json:"abs_path"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+func (r *sentryReporter) Report(ctx context.Context, event Event) {
+	e := &sentryEvent{
+		EventID:     newEventID(),
+		Timestamp:   time.Now().UTC().Format("2006-01-02T15:04:05"),
+		Level:       "error",
+		Platform:    "go",
+		Logger:      "elegant-monolith",
+		ServerName:  r.serverName,
+		Release:     buildinfo.Version,
+		Environment: r.environment,
+		Message:     event.Err.Error(),
+		Exception: sentryExceptions{Values: []sentryException{{
+			Type:       fmt.Sprintf("%T", event.cause()),
+			Value:      event.Err.Error(),
+			Stacktrace: sentryStacktrace{Frames: sentryFrames(event.stack(1))},
+		}}},
+		Tags: event.Tags,
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		e.Extra = map[string]interface{}{"request_id": id}
+	}
+
+	select {
+	case r.queue <- e:
+	default:
+		level.Warn(r.logger).Log("reporter", "sentry", "event_id", e.EventID, "err", "queue full, event dropped")
+	}
+}
+
+func (r *sentryReporter) send() {
+	for e := range r.queue {
+		if err := r.post(e); err != nil {
+			level.Warn(r.logger).Log("reporter", "sentry", "event_id", e.EventID, "err", err)
+		}
+	}
+}
+
+func (r *sentryReporter) post(e *sentryEvent) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, r.storeURL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", r.auth)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// sentryFrames converts a stack, innermost call first, to Sentry frames,
+// which list the outermost call first
+func sentryFrames(stack []uintptr) []sentryFrame {
+	var out []sentryFrame
+	frames := runtime.CallersFrames(stack)
+	for {
+		frame, more := frames.Next()
+		module, function := splitFunction(frame.Function)
+		out = append(out, sentryFrame{
+			Function: function,
+			Module:   module,
+			Filename: trimGOPATH(frame.File),
+			AbsPath:  frame.File,
+			Lineno:   frame.Line,
+			InApp:    strings.HasPrefix(frame.Function, inAppPrefix) && !strings.Contains(frame.Function, "/vendor/"),
+		})
+		if !more {
+			break
+		}
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// splitFunction splits github.com/a/b/pkg.(*T).Method into its package path
+// and (*T).Method
+func splitFunction(name string) (string, string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	return name[:slash+1+dot], name[slash+1+dot+1:]
+}
+
+func trimGOPATH(file string) string {
+	if i := strings.LastIndex(file, "/src/"); i >= 0 {
+		return file[i+len("/src/"):]
+	}
+	return file
+}
+
+func newEventID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
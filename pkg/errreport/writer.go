@@ -0,0 +1,61 @@
+package errreport
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/nathanows/elegant-monolith/pkg/requestid"
+)
+
+// NewWriterReporter returns a Reporter writing events to w as JSON lines
+func NewWriterReporter(w io.Writer, environment string) Reporter {
+	return &writerReporter{w: w, environment: environment}
+}
+
+type writerReporter struct {
+	mtx         sync.Mutex
+	w           io.Writer
+	environment string
+}
+
+type writerEvent struct {
+	Time        time.Time         `json:"time"`
+	Error       string            `json:"error"`
+	Type        string            `json:"type"`
+	RequestID   string            `json:"request_id,omitempty"`
+	Environment string            `json:"environment,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Stack       []string          `json:"stack"`
+}
+
+func (r *writerReporter) Report(ctx context.Context, event Event) {
+	e := writerEvent{
+		Time:        time.Now().UTC(),
+		Error:       event.Err.Error(),
+		Type:        fmt.Sprintf("%T", event.cause()),
+		RequestID:   requestid.FromContext(ctx),
+		Environment: r.environment,
+		Tags:        event.Tags,
+	}
+	frames := runtime.CallersFrames(event.stack(1))
+	for {
+		frame, more := frames.Next()
+		e.Stack = append(e.Stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		if !more {
+			break
+		}
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	r.w.Write(append(b, '\n'))
+}
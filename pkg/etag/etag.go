@@ -0,0 +1,81 @@
+package etag
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+)
+
+// Headers carrying etags over HTTP
+const (
+	Header        = "ETag"
+	IfMatchHeader = "If-Match"
+)
+
+// IfMatchMetadata is the gRPC metadata equivalent of IfMatchHeader
+const IfMatchMetadata = "if-match"
+
+// Any is the If-Match value matching any version
+const Any = "*"
+
+// Etag error reasons
+const (
+	ReasonInvalid  = "ETAG_INVALID"
+	ReasonMismatch = "ETAG_MISMATCH"
+)
+
+// Etag errors. ErrMismatch is answered with 412 Precondition Failed over HTTP.
+var (
+	ErrInvalid  = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonInvalid, "invalid etag")
+	ErrMismatch = apierror.New(codespb.Code_ABORTED, ReasonMismatch, "the resource was modified since it was read, fetch it again and retry")
+)
+
+// Format returns the etag of a version of a resource, e.g. "3" quotes
+// included
+func Format(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// Parse returns the version an etag was formatted from, zero for an empty
+// etag or Any. Weak etags and missing quotes are tolerated, the field
+// naming where the etag came from is reported when it is invalid.
+func Parse(tag, field string) (int64, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" || tag == Any {
+		return 0, nil
+	}
+	value := strings.Trim(strings.TrimPrefix(tag, "W/"), `"`)
+	version, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, ErrInvalid.WithFields(apierror.FieldViolation{Field: field, Description: "is not an etag returned by this server"})
+	}
+	return version, nil
+}
+
+type contextKey int
+
+const ifMatchContextKey contextKey = iota
+
+// FromContext returns the If-Match precondition placed in the context by
+// GRPCToContext, if any
+func FromContext(ctx context.Context) string {
+	tag, _ := ctx.Value(ifMatchContextKey).(string)
+	return tag
+}
+
+// GRPCToContext moves the If-Match precondition from the request metadata
+// into the context. HTTP requests get there too, headers being passed on as
+// metadata. Meant to be used as a go-kit grpctransport.ServerBefore option.
+func GRPCToContext() func(context.Context, metadata.MD) context.Context {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		if tags := md.Get(IfMatchMetadata); len(tags) > 0 && tags[0] != "" {
+			return context.WithValue(ctx, ifMatchContextKey, tags[0])
+		}
+		return ctx
+	}
+}
@@ -0,0 +1,73 @@
+package etag
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag     string
+		version int64
+	}{
+		{``, 0},
+		{` `, 0},
+		{`*`, 0},
+		{`"3"`, 3},
+		{` "3" `, 3},
+		{`W/"3"`, 3},
+		{`3`, 3},
+		{Format(42), 42},
+	}
+	for _, tt := range tests {
+		version, err := Parse(tt.tag, "etag")
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", tt.tag, err)
+			continue
+		}
+		if version != tt.version {
+			t.Errorf("Parse(%q) = %d, want %d", tt.tag, version, tt.version)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tag := range []string{`"abc"`, `"0"`, `"-1"`, `"3.5"`, `W/`, `"99999999999999999999"`} {
+		_, err := Parse(tag, "If-Match")
+		e, ok := err.(*apierror.Error)
+		if !ok {
+			t.Errorf("Parse(%q) error = %v, want an *apierror.Error", tag, err)
+			continue
+		}
+		if e.Reason != ReasonInvalid || len(e.Fields) != 1 || e.Fields[0].Field != "If-Match" {
+			t.Errorf("Parse(%q) error = %+v, want %s on If-Match", tag, e, ReasonInvalid)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	if tag := Format(3); tag != `"3"` {
+		t.Errorf("Format(3) = %s, want \"3\"", tag)
+	}
+}
+
+func TestGRPCToContext(t *testing.T) {
+	tests := []struct {
+		md  metadata.MD
+		tag string
+	}{
+		{metadata.MD{}, ""},
+		{metadata.Pairs(IfMatchMetadata, ""), ""},
+		{metadata.Pairs(IfMatchMetadata, `"3"`), `"3"`},
+	}
+	for _, tt := range tests {
+		ctx := GRPCToContext()(context.Background(), tt.md)
+		if tag := FromContext(ctx); tag != tt.tag {
+			t.Errorf("FromContext() after %v = %q, want %q", tt.md, tag, tt.tag)
+		}
+	}
+}
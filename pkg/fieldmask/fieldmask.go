@@ -0,0 +1,98 @@
+package fieldmask
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gogo/protobuf/types"
+
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+)
+
+// ReasonInvalidUpdateMask is the reason of ErrInvalidUpdateMask
+const ReasonInvalidUpdateMask = "UPDATE_MASK_INVALID"
+
+// ErrInvalidUpdateMask is returned with a violation of each invalid path of
+// an update mask
+var ErrInvalidUpdateMask = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonInvalidUpdateMask, "invalid update mask")
+
+// Wildcard is the path standing for every mutable field
+const Wildcard = "*"
+
+// Fields are the fields of a resource an update mask may name, by proto name
+type Fields struct {
+	// Mutable can be updated
+	Mutable []string
+	// Immutable are set on creation or by the server, and rejected in masks
+	Immutable []string
+}
+
+// Paths checks the paths of mask, returning the fields an update sets: the
+// paths themselves, or every mutable field when mask is empty or holds the
+// wildcard
+func (f Fields) Paths(mask *types.FieldMask) ([]string, error) {
+	if len(mask.GetPaths()) == 0 {
+		return f.Mutable, nil
+	}
+
+	var violations []apierror.FieldViolation
+	violate := func(i int, format string, args ...interface{}) {
+		violations = append(violations, apierror.FieldViolation{
+			Field:       fmt.Sprintf("update_mask.paths[%d]", i),
+			Description: fmt.Sprintf(format, args...),
+		})
+	}
+
+	var (
+		paths    []string
+		wildcard = -1
+		seen     = map[string]bool{}
+	)
+	for i, path := range mask.GetPaths() {
+		switch {
+		case path == Wildcard:
+			wildcard = i
+		case contains(f.Immutable, path):
+			violate(i, "%q can't be updated", path)
+		case !contains(f.Mutable, path):
+			violate(i, "unknown field %q, updatable fields are %s", path, strings.Join(f.Mutable, ", "))
+		case seen[path]:
+			violate(i, "%q is listed twice", path)
+		default:
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	if wildcard >= 0 && len(mask.GetPaths()) > 1 {
+		violate(wildcard, "%q can't be combined with other paths", Wildcard)
+	}
+	if len(violations) > 0 {
+		return nil, ErrInvalidUpdateMask.WithFields(violations...)
+	}
+	if wildcard >= 0 {
+		return f.Mutable, nil
+	}
+	return paths, nil
+}
+
+// Covers reports whether the field at path, relative to the resource, is
+// part of the fields an update sets. Paths of nested fields, e.g.
+// address.city or scopes[1], are covered by their parent.
+func Covers(paths []string, path string) bool {
+	for _, p := range paths {
+		if path == p || strings.HasPrefix(path, p+".") || strings.HasPrefix(path, p+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package fieldmask
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+)
+
+var testFields = Fields{
+	Mutable:   []string{"name", "address"},
+	Immutable: []string{"id", "created_at"},
+}
+
+func TestPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		mask  *types.FieldMask
+		paths []string
+	}{
+		{"no mask", nil, []string{"name", "address"}},
+		{"empty mask", &types.FieldMask{}, []string{"name", "address"}},
+		{"wildcard", &types.FieldMask{Paths: []string{"*"}}, []string{"name", "address"}},
+		{"some fields", &types.FieldMask{Paths: []string{"address"}}, []string{"address"}},
+		{"in mask order", &types.FieldMask{Paths: []string{"address", "name"}}, []string{"address", "name"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths, err := testFields.Paths(tt.mask)
+			if err != nil {
+				t.Fatalf("Paths(%v) failed: %v", tt.mask, err)
+			}
+			if !reflect.DeepEqual(paths, tt.paths) {
+				t.Errorf("Paths(%v) = %q, want %q", tt.mask, paths, tt.paths)
+			}
+		})
+	}
+}
+
+func TestPathsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		paths  []string
+		fields []string
+	}{
+		{"immutable", []string{"name", "created_at"}, []string{"update_mask.paths[1]"}},
+		{"unknown", []string{"email"}, []string{"update_mask.paths[0]"}},
+		{"twice", []string{"name", "address", "name"}, []string{"update_mask.paths[2]"}},
+		{"wildcard with paths", []string{"name", "*"}, []string{"update_mask.paths[1]"}},
+		{"several", []string{"id", "name", "email"}, []string{"update_mask.paths[0]", "update_mask.paths[2]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := testFields.Paths(&types.FieldMask{Paths: tt.paths})
+			e, ok := err.(*apierror.Error)
+			if !ok {
+				t.Fatalf("Paths(%q) error = %v, want an *apierror.Error", tt.paths, err)
+			}
+			if e.Reason != ReasonInvalidUpdateMask {
+				t.Errorf("Paths(%q) reason = %s, want %s", tt.paths, e.Reason, ReasonInvalidUpdateMask)
+			}
+			var fields []string
+			for _, v := range e.Fields {
+				fields = append(fields, v.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("Paths(%q) violations on %q, want %q", tt.paths, fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestCovers(t *testing.T) {
+	paths := []string{"name", "address", "scopes"}
+	tests := []struct {
+		path   string
+		covers bool
+	}{
+		{"name", true},
+		{"address.city", true},
+		{"scopes[1]", true},
+		{"id", false},
+		{"names", false},
+		{"addressee", false},
+	}
+	for _, tt := range tests {
+		if got := Covers(paths, tt.path); got != tt.covers {
+			t.Errorf("Covers(%q, %q) = %v, want %v", paths, tt.path, got, tt.covers)
+		}
+	}
+}
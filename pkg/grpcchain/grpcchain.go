@@ -0,0 +1,42 @@
+package grpcchain
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryServer combines interceptors into one, the first being the outermost.
+// grpc.NewServer only accepts a single interceptor of each kind.
+func UnaryServer(interceptors ...grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			chained = bindUnary(interceptors[i], info, chained)
+		}
+		return chained(ctx, req)
+	}
+}
+
+func bindUnary(interceptor grpc.UnaryServerInterceptor, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) grpc.UnaryHandler {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return interceptor(ctx, req, info, next)
+	}
+}
+
+// StreamServer combines interceptors into one, the first being the outermost
+func StreamServer(interceptors ...grpc.StreamServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		chained := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			chained = bindStream(interceptors[i], info, chained)
+		}
+		return chained(srv, ss)
+	}
+}
+
+func bindStream(interceptor grpc.StreamServerInterceptor, info *grpc.StreamServerInfo, next grpc.StreamHandler) grpc.StreamHandler {
+	return func(srv interface{}, ss grpc.ServerStream) error {
+		return interceptor(srv, ss, info, next)
+	}
+}
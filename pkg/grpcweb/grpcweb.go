@@ -0,0 +1,178 @@
+package grpcweb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+)
+
+// Content types of gRPC-Web requests, binary and base64 encoded. Either may
+// carry a +proto suffix.
+const (
+	ContentType     = "application/grpc-web"
+	ContentTypeText = "application/grpc-web-text"
+)
+
+// RequestHeaders are the headers browser clients send with gRPC-Web calls,
+// they must be allowed by CORS
+var RequestHeaders = []string{"Content-Type", "X-Grpc-Web", "X-User-Agent", "Grpc-Timeout"}
+
+// ResponseHeaders are the headers gRPC-Web clients read from responses
+// without trailers, they must be exposed by CORS
+var ResponseHeaders = []string{"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin"}
+
+// trailerFlag marks a frame of the response body as carrying the trailers
+const trailerFlag = 0x80
+
+// IsGRPCWebRequest reports whether r is a gRPC-Web call
+func IsGRPCWebRequest(r *http.Request) bool {
+	return r.Method == "POST" && strings.HasPrefix(r.Header.Get("Content-Type"), ContentType)
+}
+
+// Middleware returns a middleware that serves gRPC-Web calls with server,
+// passing every other request on to the wrapped handler. The calls go through
+// the server's interceptors just like native gRPC ones.
+func Middleware(server *grpc.Server) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !IsGRPCWebRequest(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			serve(server, w, r)
+		})
+	}
+}
+
+// serve translates the gRPC-Web call into the HTTP/2 request grpc.Server
+// expects, and its response back into gRPC-Web, moving the trailers into the
+// body since browsers can't read HTTP trailers
+func serve(server *grpc.Server, w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	text := strings.HasPrefix(contentType, ContentTypeText)
+
+	req := r.WithContext(r.Context())
+	req.Proto, req.ProtoMajor, req.ProtoMinor = "HTTP/2", 2, 0
+	req.Header = make(http.Header, len(r.Header))
+	for key, values := range r.Header {
+		req.Header[key] = values
+	}
+	req.Header.Del("Content-Length")
+	if text {
+		req.Header.Set("Content-Type", "application/grpc"+strings.TrimPrefix(contentType, ContentTypeText))
+		req.Body = ioutil.NopCloser(base64.NewDecoder(base64.StdEncoding, r.Body))
+	} else {
+		req.Header.Set("Content-Type", "application/grpc"+strings.TrimPrefix(contentType, ContentType))
+	}
+
+	rw := &responseWriter{
+		w:           w,
+		out:         w,
+		header:      http.Header{},
+		contentType: contentType,
+	}
+	var encoder io.WriteCloser
+	if text {
+		encoder = base64.NewEncoder(base64.StdEncoding, w)
+		rw.out = encoder
+	}
+
+	server.ServeHTTP(rw, req)
+
+	rw.writeTrailers()
+	if encoder != nil {
+		encoder.Close()
+	}
+	rw.Flush()
+}
+
+// responseWriter hands grpc.Server a writer with the capabilities of an HTTP/2
+// one. Headers set after the response header was written are trailers.
+type responseWriter struct {
+	w           http.ResponseWriter
+	out         io.Writer
+	header      http.Header
+	contentType string
+	wroteHeader bool
+}
+
+func (rw *responseWriter) Header() http.Header {
+	return rw.header
+}
+
+func (rw *responseWriter) WriteHeader(code int) {
+	if rw.wroteHeader {
+		return
+	}
+	rw.wroteHeader = true
+
+	h := rw.w.Header()
+	for key, values := range rw.header {
+		if key == "Trailer" || strings.HasPrefix(key, http2.TrailerPrefix) {
+			continue
+		}
+		h[key] = values
+	}
+	h.Set("Content-Type", rw.contentType)
+	h.Del("Content-Length")
+	rw.w.WriteHeader(code)
+}
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	rw.WriteHeader(http.StatusOK)
+	return rw.out.Write(b)
+}
+
+func (rw *responseWriter) Flush() {
+	rw.WriteHeader(http.StatusOK)
+	if flusher, ok := rw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (rw *responseWriter) CloseNotify() <-chan bool {
+	if notifier, ok := rw.w.(http.CloseNotifier); ok {
+		return notifier.CloseNotify()
+	}
+	return make(chan bool)
+}
+
+// writeTrailers writes the declared and undeclared trailers as the final
+// frame of the body
+func (rw *responseWriter) writeTrailers() {
+	rw.WriteHeader(http.StatusOK)
+
+	trailers := http.Header{}
+	for _, declared := range rw.header["Trailer"] {
+		for _, key := range strings.Split(declared, ",") {
+			key = http.CanonicalHeaderKey(strings.TrimSpace(key))
+			if values, ok := rw.header[key]; ok {
+				trailers[key] = values
+			}
+		}
+	}
+	for key, values := range rw.header {
+		if strings.HasPrefix(key, http2.TrailerPrefix) {
+			trailers[http.CanonicalHeaderKey(strings.TrimPrefix(key, http2.TrailerPrefix))] = values
+		}
+	}
+
+	var body bytes.Buffer
+	for key, values := range trailers {
+		for _, value := range values {
+			body.WriteString(strings.ToLower(key) + ": " + value + "\r\n")
+		}
+	}
+
+	frame := make([]byte, 5, 5+body.Len())
+	frame[0] = trailerFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(body.Len()))
+	rw.out.Write(append(frame, body.Bytes()...))
+}
@@ -0,0 +1,157 @@
+package httpcodec
+
+import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+)
+
+// Request body error reasons
+const (
+	ReasonMalformedGzip = "GZIP_MALFORMED"
+	ReasonBodyTooLarge  = "REQUEST_TOO_LARGE"
+)
+
+// ErrMalformedGzip is returned for request bodies announced as gzip that
+// aren't
+var ErrMalformedGzip = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonMalformedGzip, "malformed gzip request body")
+
+// MaxDecompressedBody bounds gzip request bodies once decompressed, a few
+// kilobytes of gzip being enough to expand to gigabytes. It matches the
+// default maximum message size of gRPC servers.
+const MaxDecompressedBody = 4 << 20
+
+var gzipWriters = sync.Pool{
+	New: func() interface{} {
+		return gzip.NewWriter(nil)
+	},
+}
+
+// GzipMiddleware transparently decompresses request bodies sent with
+// Content-Encoding: gzip, and compresses responses for clients advertising
+// gzip in Accept-Encoding. Decompressed bodies larger than
+// MaxDecompressedBody fail to read with a TooLargeError.
+func GzipMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+			body, err := gzip.NewReader(r.Body)
+			if err != nil {
+				ErrMalformedGzip.WriteHTTP(w, ErrMalformedGzip.HTTPStatus())
+				return
+			}
+			defer body.Close()
+			r.Body = &limitedBody{ReadCloser: body, remaining: MaxDecompressedBody}
+			r.Header.Del("Content-Encoding")
+			r.Header.Del("Content-Length")
+			r.ContentLength = -1
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		if !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		gz := gzipWriters.Get().(*gzip.Writer)
+		gz.Reset(w)
+		gw := &gzipResponseWriter{ResponseWriter: w, gz: gz}
+		defer func() {
+			gw.close()
+			gzipWriters.Put(gz)
+		}()
+		next.ServeHTTP(gw, r)
+	})
+}
+
+// limitedBody fails reads past the given number of bytes with a
+// TooLargeError
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	// read one byte past the limit to tell a body of exactly the limit from
+	// a larger one
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) > b.remaining {
+		n, b.remaining = int(b.remaining), 0
+		return n, &TooLargeError{Limit: MaxDecompressedBody}
+	}
+	b.remaining -= int64(n)
+	return n, err
+}
+
+func acceptsGzip(acceptEncoding string) bool {
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(part, ";")
+		if coding := strings.TrimSpace(params[0]); coding != "gzip" && coding != "*" {
+			continue
+		}
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil && q <= 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// gzipResponseWriter compresses everything written through it. Compression
+// is decided when the header is written, so handlers that set their own
+// Content-Encoding are passed through untouched.
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	gz          *gzip.Writer
+	wroteHeader bool
+	compress    bool
+}
+
+func (w *gzipResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	h := w.Header()
+	w.compress = h.Get("Content-Encoding") == "" && status != http.StatusNoContent && status != http.StatusNotModified
+	if w.compress {
+		h.Set("Content-Encoding", "gzip")
+		h.Del("Content-Length")
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *gzipResponseWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.compress {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.gz.Write(b)
+}
+
+func (w *gzipResponseWriter) close() {
+	if w.compress {
+		w.gz.Close()
+	} else {
+		// nothing was compressed, detach from the underlying writer without
+		// emitting a gzip footer
+		w.gz.Reset(ioutil.Discard)
+	}
+}
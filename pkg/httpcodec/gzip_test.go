@@ -0,0 +1,152 @@
+package httpcodec
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+)
+
+func gzipped(t *testing.T, body string) []byte {
+	t.Helper()
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	if _, err := gz.Write([]byte(body)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+// decodeHandler decodes its request into a StringValue and echoes it
+func decodeHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := &types.StringValue{}
+		if err := DecodeRequest(r, msg); err != nil {
+			if _, ok := err.(*TooLargeError); ok {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				return
+			}
+			t.Errorf("DecodeRequest() failed: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte(msg.Value))
+	})
+}
+
+func TestGzipRequest(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", bytes.NewReader(gzipped(t, `"acme"`)))
+	r.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	GzipMiddleware(decodeHandler(t)).ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Body.String() != "acme" {
+		t.Errorf("response = %d %q, want 200 \"acme\"", w.Code, w.Body.String())
+	}
+}
+
+func TestGzipMalformedRequest(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`"acme"`))
+	r.Header.Set("Content-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	GzipMiddleware(decodeHandler(t)).ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	var body apierror.HTTPBody
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("body %q isn't an error body: %v", w.Body.String(), err)
+	}
+	if body.Reason != ReasonMalformedGzip {
+		t.Errorf("reason = %s, want %s", body.Reason, ReasonMalformedGzip)
+	}
+}
+
+func TestGzipRequestTooLarge(t *testing.T) {
+	value := func(n int) string { return `"` + strings.Repeat("a", n-2) + `"` }
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+	}{
+		{"at the limit", ContentTypeJSON, value(MaxDecompressedBody), http.StatusOK},
+		{"past the limit", ContentTypeJSON, value(MaxDecompressedBody + 1), http.StatusRequestEntityTooLarge},
+		{"protobuf past the limit", ContentTypeProtobuf, strings.Repeat("a", MaxDecompressedBody+1), http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", bytes.NewReader(gzipped(t, tt.body)))
+			r.Header.Set("Content-Encoding", "gzip")
+			r.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			GzipMiddleware(decodeHandler(t)).ServeHTTP(w, r)
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
+
+func TestGzipResponse(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		compressed     bool
+	}{
+		{"", false},
+		{"gzip", true},
+		{"deflate, gzip;q=0.5", true},
+		{"*", true},
+		{"gzip;q=0", false},
+		{"identity", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Header.Set("Accept-Encoding", tt.acceptEncoding)
+		w := httptest.NewRecorder()
+		GzipMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("acme"))
+		})).ServeHTTP(w, r)
+
+		compressed := w.Header().Get("Content-Encoding") == "gzip"
+		if compressed != tt.compressed {
+			t.Errorf("Accept-Encoding %q compressed = %v, want %v", tt.acceptEncoding, compressed, tt.compressed)
+			continue
+		}
+		body := w.Body.Bytes()
+		if compressed {
+			gz, err := gzip.NewReader(w.Body)
+			if err != nil {
+				t.Fatalf("Accept-Encoding %q: %v", tt.acceptEncoding, err)
+			}
+			if body, err = ioutil.ReadAll(gz); err != nil {
+				t.Fatalf("Accept-Encoding %q: %v", tt.acceptEncoding, err)
+			}
+		}
+		if string(body) != "acme" {
+			t.Errorf("Accept-Encoding %q body = %q, want \"acme\"", tt.acceptEncoding, body)
+		}
+	}
+}
+
+func TestGzipResponseNoContent(t *testing.T) {
+	r := httptest.NewRequest("DELETE", "/", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	GzipMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})).ServeHTTP(w, r)
+	if w.Header().Get("Content-Encoding") != "" || w.Body.Len() != 0 {
+		t.Errorf("204 response compressed: %q, %q", w.Header().Get("Content-Encoding"), w.Body.Bytes())
+	}
+}
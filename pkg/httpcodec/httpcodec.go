@@ -0,0 +1,171 @@
+package httpcodec
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+)
+
+// Supported media types. JSON is the default when a client doesn't ask for
+// anything in particular.
+const (
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// The HTTP transports speak the proto3 JSON mapping rather than
+// encoding/json's rendering of the generated structs, so REST and gRPC
+// clients share one contract: lowerCamelCase field names, RFC 3339
+// timestamps and 64 bit integers as strings.
+var (
+	marshaler   = jsonpb.Marshaler{}
+	unmarshaler = jsonpb.Unmarshaler{AllowUnknownFields: false}
+)
+
+// DecodeError is returned when a request body can't be decoded into the
+// expected message, and should be reported to the client as a bad request
+type DecodeError struct {
+	Err error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("malformed request body: %s", e.Err)
+}
+
+// UnsupportedMediaTypeError is returned when a request body is sent in a
+// format the transports don't speak
+type UnsupportedMediaTypeError struct {
+	ContentType string
+}
+
+func (e *UnsupportedMediaTypeError) Error() string {
+	return fmt.Sprintf("unsupported content type %q, expected %s or %s", e.ContentType, ContentTypeJSON, ContentTypeProtobuf)
+}
+
+// TooLargeError is returned when a request body exceeds the size the
+// transports accept, and should be reported to the client as 413
+type TooLargeError struct {
+	Limit int64
+}
+
+func (e *TooLargeError) Error() string {
+	return fmt.Sprintf("request body exceeds %d bytes", e.Limit)
+}
+
+// DecodeRequest decodes the request body into msg according to its
+// Content-Type, binary protobuf or JSON. An empty body leaves msg untouched.
+func DecodeRequest(r *http.Request, msg proto.Message) error {
+	contentType := r.Header.Get("Content-Type")
+	mediaType := ContentTypeJSON
+	if contentType != "" {
+		var err error
+		if mediaType, _, err = mime.ParseMediaType(contentType); err != nil {
+			return &UnsupportedMediaTypeError{contentType}
+		}
+	}
+
+	switch {
+	case isProtobuf(mediaType):
+		body, err := ioutil.ReadAll(r.Body)
+		if tooLarge, ok := err.(*TooLargeError); ok {
+			return tooLarge
+		}
+		if err != nil {
+			return &DecodeError{err}
+		}
+		if err := proto.Unmarshal(body, msg); err != nil {
+			return &DecodeError{err}
+		}
+	case isJSON(mediaType):
+		err := unmarshaler.Unmarshal(r.Body, msg)
+		if tooLarge, ok := err.(*TooLargeError); ok {
+			return tooLarge
+		}
+		if err != nil && err != io.EOF {
+			return &DecodeError{err}
+		}
+	default:
+		return &UnsupportedMediaTypeError{contentType}
+	}
+	return nil
+}
+
+// EncodeResponse is a go-kit EncodeResponseFunc writing the proto message
+// response as binary protobuf or JSON, whichever the client prefers. It relies
+// on the Accept header having been placed in the context by
+// httptransport.PopulateRequestContext.
+func EncodeResponse(ctx context.Context, w http.ResponseWriter, response interface{}) error {
+	msg, ok := response.(proto.Message)
+	if !ok {
+		return fmt.Errorf("httpcodec: unable to encode %T, not a proto message", response)
+	}
+
+	accept, _ := ctx.Value(httptransport.ContextKeyRequestAccept).(string)
+	w.Header().Add("Vary", "Accept")
+	if negotiate(accept) == ContentTypeProtobuf {
+		body, err := proto.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		w.Header().Set("Content-Type", ContentTypeProtobuf)
+		_, err = w.Write(body)
+		return err
+	}
+
+	w.Header().Set("Content-Type", ContentTypeJSON+"; charset=utf-8")
+	return marshaler.Marshal(w, msg)
+}
+
+func isProtobuf(mediaType string) bool {
+	return mediaType == ContentTypeProtobuf || mediaType == "application/protobuf"
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == ContentTypeJSON || strings.HasSuffix(mediaType, "+json")
+}
+
+// negotiate picks the supported media type ranked highest by the Accept
+// header, falling back to JSON
+func negotiate(accept string) string {
+	type mediaRange struct {
+		mediaType string
+		q         float64
+	}
+
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType, q})
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].q > ranges[j].q })
+
+	for _, r := range ranges {
+		switch {
+		case r.q <= 0:
+		case isProtobuf(r.mediaType):
+			return ContentTypeProtobuf
+		case isJSON(r.mediaType), r.mediaType == "application/*", r.mediaType == "*/*":
+			return ContentTypeJSON
+		}
+	}
+	return ContentTypeJSON
+}
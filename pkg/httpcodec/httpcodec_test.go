@@ -0,0 +1,127 @@
+package httpcodec
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+)
+
+func TestDecodeRequest(t *testing.T) {
+	binary, err := proto.Marshal(&types.StringValue{Value: "acme"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		value       string
+	}{
+		{"JSON by default", "", `"acme"`, "acme"},
+		{"JSON", "application/json; charset=utf-8", `"acme"`, "acme"},
+		{"JSON suffix", "application/merge-patch+json", `"acme"`, "acme"},
+		{"protobuf", ContentTypeProtobuf, string(binary), "acme"},
+		{"protobuf alias", "application/protobuf", string(binary), "acme"},
+		{"empty body", ContentTypeJSON, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			msg := &types.StringValue{}
+			if err := DecodeRequest(r, msg); err != nil {
+				t.Fatalf("DecodeRequest() failed: %v", err)
+			}
+			if msg.Value != tt.value {
+				t.Errorf("DecodeRequest() = %q, want %q", msg.Value, tt.value)
+			}
+		})
+	}
+}
+
+func TestDecodeRequestErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		check       func(error) bool
+	}{
+		{
+			"unsupported type", "text/plain", "acme",
+			func(err error) bool { _, ok := err.(*UnsupportedMediaTypeError); return ok },
+		},
+		{
+			"invalid type", "application/", "acme",
+			func(err error) bool { _, ok := err.(*UnsupportedMediaTypeError); return ok },
+		},
+		{
+			"malformed JSON", ContentTypeJSON, `{"value"`,
+			func(err error) bool { _, ok := err.(*DecodeError); return ok },
+		},
+		{
+			"malformed protobuf", ContentTypeProtobuf, "\xff",
+			func(err error) bool { _, ok := err.(*DecodeError); return ok },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			if err := DecodeRequest(r, &types.StringValue{}); !tt.check(err) {
+				t.Errorf("DecodeRequest() error = %#v", err)
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", ContentTypeJSON},
+		{"*/*", ContentTypeJSON},
+		{"text/html", ContentTypeJSON},
+		{ContentTypeProtobuf, ContentTypeProtobuf},
+		{"application/protobuf", ContentTypeProtobuf},
+		{"application/json, application/x-protobuf", ContentTypeJSON},
+		{"application/json;q=0.5, application/x-protobuf", ContentTypeProtobuf},
+		{"application/x-protobuf;q=0, */*", ContentTypeJSON},
+	}
+	for _, tt := range tests {
+		if got := negotiate(tt.accept); got != tt.want {
+			t.Errorf("negotiate(%q) = %s, want %s", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeResponse(t *testing.T) {
+	tests := []struct {
+		accept      string
+		contentType string
+		body        string
+	}{
+		{"", "application/json; charset=utf-8", `"acme"`},
+		{ContentTypeProtobuf, ContentTypeProtobuf, "\n\x04acme"},
+	}
+	for _, tt := range tests {
+		ctx := context.WithValue(context.Background(), httptransport.ContextKeyRequestAccept, tt.accept)
+		w := httptest.NewRecorder()
+		if err := EncodeResponse(ctx, w, &types.StringValue{Value: "acme"}); err != nil {
+			t.Fatalf("EncodeResponse(%q) failed: %v", tt.accept, err)
+		}
+		if got := w.Header().Get("Content-Type"); got != tt.contentType {
+			t.Errorf("EncodeResponse(%q) Content-Type = %s, want %s", tt.accept, got, tt.contentType)
+		}
+		if got := w.Body.String(); got != tt.body {
+			t.Errorf("EncodeResponse(%q) body = %q, want %q", tt.accept, got, tt.body)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"github.com/gogo/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+	"github.com/nathanows/elegant-monolith/pkg/auth"
+)
+
+// Header is the HTTP header clients pass an idempotency key in
+const Header = "Idempotency-Key"
+
+// Metadata is the gRPC metadata equivalent of Header
+const Metadata = "idempotency-key"
+
+// ReplayedMetadata is set on responses replayed from a previous request, it
+// reaches HTTP clients as the Idempotent-Replayed header
+const ReplayedMetadata = "idempotent-replayed"
+
+// maxKeyLength bounds the keys clients may choose, UUIDs fit comfortably
+const maxKeyLength = 255
+
+// DefaultWindow is how long keys are remembered when Config.Window is zero
+const DefaultWindow = 24 * time.Hour
+
+// DefaultLease is how long a request may hold its key when Config.Lease is
+// zero
+const DefaultLease = time.Minute
+
+// Idempotency error reasons
+const (
+	ReasonInvalidKey = "IDEMPOTENCY_KEY_INVALID"
+	ReasonKeyReused  = "IDEMPOTENCY_KEY_REUSED"
+	ReasonKeyInUse   = "IDEMPOTENCY_KEY_IN_USE"
+	ReasonStore      = "IDEMPOTENCY_STORE_UNAVAILABLE"
+)
+
+// Idempotency errors
+var (
+	ErrInvalidKey = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonInvalidKey, "invalid idempotency key").
+			WithFields(apierror.FieldViolation{Field: Header, Description: fmt.Sprintf("must be 1 to %d characters", maxKeyLength)})
+	ErrKeyReused = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonKeyReused, "idempotency key already used with a different request").
+			WithFields(apierror.FieldViolation{Field: Header, Description: "was used with a different request, use a new key"})
+	ErrKeyInUse = apierror.New(codespb.Code_ABORTED, ReasonKeyInUse, "a request with this idempotency key is in progress, retry later")
+	ErrStore    = apierror.New(codespb.Code_INTERNAL, ReasonStore, "unable to query idempotency keys")
+)
+
+// Config controls how long idempotency keys are remembered
+type Config struct {
+	// Window is how long, in seconds, a key and the response to its request
+	// are kept. Retries past the window run again. Defaults to a day.
+	Window int
+	// Lease is how long, in seconds, a request holds its key. Keys of
+	// requests that neither completed nor failed within it, e.g. when the
+	// process died, are taken over by retries. It must exceed the longest
+	// request. Defaults to a minute.
+	Lease int
+}
+
+// Keys makes endpoints safe to retry with idempotency keys, remembering
+// them in a Store
+type Keys struct {
+	store  Store
+	window time.Duration
+	lease  time.Duration
+	logger log.Logger
+}
+
+// NewKeys returns Keys remembered in store for the window of config
+func NewKeys(store Store, config Config, logger log.Logger) *Keys {
+	k := &Keys{store: store, window: DefaultWindow, lease: DefaultLease, logger: logger}
+	if config.Window > 0 {
+		k.window = time.Duration(config.Window) * time.Second
+	}
+	if config.Lease > 0 {
+		k.lease = time.Duration(config.Lease) * time.Second
+	}
+	return k
+}
+
+// Record is what is remembered of a request made with a key
+type Record struct {
+	Fingerprint string
+	// Response is the marshaled response, nil while the request is in
+	// progress
+	Response []byte
+}
+
+// Store remembers idempotency keys. Keys are namespaced by scope, the method
+// and caller they were used for. Each reservation of a key is identified by
+// a token, so a request whose lease was taken over can't complete or
+// release the reservation of the request that took it over.
+type Store interface {
+	// Reserve records a key for a request about to run under token. It
+	// returns nil when the key was free, expired, or held for longer than
+	// lease by a request still in progress, and the existing record
+	// otherwise.
+	Reserve(ctx context.Context, scope, key, token, fingerprint string, window, lease time.Duration) (*Record, error)
+	// Complete stores the response of the request holding a key under
+	// token, if it still holds it
+	Complete(ctx context.Context, scope, key, token string, response []byte) error
+	// Release forgets a key held under token whose request failed, so it
+	// can be retried
+	Release(ctx context.Context, scope, key, token string) error
+	// Purge forgets the keys older than window, returning how many
+	Purge(ctx context.Context, window time.Duration) (int64, error)
+}
+
+// purgeInterval is how often PurgeExpired runs
+const purgeInterval = time.Hour
+
+// PurgeExpired forgets expired keys every hour until ctx is done. Reserve
+// takes expired keys over anyway, purging keeps the store from growing.
+func (k *Keys) PurgeExpired(ctx context.Context) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			purged, err := k.store.Purge(ctx, k.window)
+			if err != nil {
+				level.Error(k.logger).Log("during", "Purge", "err", err)
+				continue
+			}
+			level.Debug(k.logger).Log("purged", purged)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+type contextKey int
+
+const keyContextKey contextKey = iota
+
+// GRPCToContext moves the idempotency key from the request metadata into the
+// context. HTTP requests get there too, headers being passed on as metadata.
+// Meant to be used as a go-kit grpctransport.ServerBefore option.
+func GRPCToContext() func(context.Context, metadata.MD) context.Context {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		if keys := md.Get(Metadata); len(keys) > 0 && keys[0] != "" {
+			return context.WithValue(ctx, keyContextKey, keys[0])
+		}
+		return ctx
+	}
+}
+
+// Middleware returns an endpoint middleware making requests carrying an
+// idempotency key safe to retry: the first request runs and its response is
+// stored, retries with the same key and request get the stored response,
+// and reusing the key for another request fails with ErrKeyReused while the
+// first one is remembered. Failed requests aren't remembered, nor are
+// requests past the lease of their key. newResponse returns an empty response of the
+// endpoint to unmarshal stored ones into.
+func (k *Keys) Middleware(service, method string, newResponse func() proto.Message) endpoint.Middleware {
+	method = service + "." + method
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			key, ok := ctx.Value(keyContextKey).(string)
+			if !ok {
+				return next(ctx, request)
+			}
+			if len(key) > maxKeyLength {
+				return nil, ErrInvalidKey
+			}
+			msg, ok := request.(proto.Message)
+			if !ok {
+				return next(ctx, request)
+			}
+			fingerprint, err := fingerprint(method, msg)
+			if err != nil {
+				return nil, apierror.ErrInternal
+			}
+
+			token, err := newToken()
+			if err != nil {
+				return nil, apierror.ErrInternal
+			}
+			scope := method + ":" + caller(ctx)
+			existing, err := k.store.Reserve(ctx, scope, key, token, fingerprint, k.window, k.lease)
+			if err != nil {
+				level.Error(k.logger).Log("method", method, "during", "Reserve", "err", err)
+				return nil, ErrStore
+			}
+			if existing != nil {
+				switch {
+				case existing.Fingerprint != fingerprint:
+					return nil, ErrKeyReused
+				case existing.Response == nil:
+					return nil, ErrKeyInUse
+				}
+				response := newResponse()
+				if err := proto.Unmarshal(existing.Response, response); err != nil {
+					level.Error(k.logger).Log("method", method, "during", "Unmarshal", "err", err)
+					return nil, ErrStore
+				}
+				grpc.SetHeader(ctx, metadata.Pairs(ReplayedMetadata, "true"))
+				return response, nil
+			}
+
+			response, err := next(ctx, request)
+			if err != nil {
+				// a failed request may be retried with the same key
+				k.release(method, scope, key, token)
+				return nil, err
+			}
+			if msg, ok := response.(proto.Message); ok {
+				if b, err := proto.Marshal(msg); err == nil {
+					// the response is sent even if it can't be stored, the
+					// key is then taken over once its lease is over
+					if err := k.store.Complete(context.Background(), scope, key, token, b); err != nil {
+						level.Error(k.logger).Log("method", method, "during", "Complete", "err", err)
+					}
+					return response, nil
+				}
+			}
+			k.release(method, scope, key, token)
+			return response, nil
+		}
+	}
+}
+
+// release forgets a key, which is otherwise taken over once its lease is
+// over
+func (k *Keys) release(method, scope, key, token string) {
+	if err := k.store.Release(context.Background(), scope, key, token); err != nil {
+		level.Error(k.logger).Log("method", method, "during", "Release", "err", err)
+	}
+}
+
+// newToken returns a token identifying a reservation of a key
+func newToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// fingerprint identifies a request to a method, to detect a key being reused
+// for another request
+func fingerprint(method string, request proto.Message) (string, error) {
+	b, err := proto.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(append([]byte(method+"\x00"), b...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// caller identifies the authenticated caller, so callers can't see each
+// other's responses by picking the same key
+func caller(ctx context.Context) string {
+	if principal, ok := auth.FromContext(ctx); ok {
+		return fmt.Sprintf("%s:%d", principal.Kind, principal.ID)
+	}
+	return "anonymous"
+}
@@ -0,0 +1,269 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kit/kit/log"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/nathanows/elegant-monolith/pkg/auth"
+)
+
+// memoryStore is a Store keeping keys in a map, failing with err when set
+type memoryStore struct {
+	records map[string]*Record
+	tokens  map[string]string
+	err     error
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: map[string]*Record{}, tokens: map[string]string{}}
+}
+
+func (s *memoryStore) Reserve(_ context.Context, scope, key, token, fingerprint string, _, _ time.Duration) (*Record, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	if r, ok := s.records[scope+"/"+key]; ok {
+		return r, nil
+	}
+	s.records[scope+"/"+key] = &Record{Fingerprint: fingerprint}
+	s.tokens[scope+"/"+key] = token
+	return nil, nil
+}
+
+func (s *memoryStore) Complete(_ context.Context, scope, key, token string, response []byte) error {
+	if s.tokens[scope+"/"+key] == token {
+		s.records[scope+"/"+key].Response = response
+	}
+	return nil
+}
+
+func (s *memoryStore) Release(_ context.Context, scope, key, token string) error {
+	if s.tokens[scope+"/"+key] == token {
+		delete(s.records, scope+"/"+key)
+		delete(s.tokens, scope+"/"+key)
+	}
+	return nil
+}
+
+// expire forgets a key as Reserve does once its lease is over
+func (s *memoryStore) expire(scope, key string) {
+	delete(s.records, scope+"/"+key)
+	delete(s.tokens, scope+"/"+key)
+}
+
+func (s *memoryStore) Purge(context.Context, time.Duration) (int64, error) {
+	return 0, nil
+}
+
+// headerStream captures the headers set by handlers
+type headerStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+// counter is an endpoint echoing its request, counting its calls, failing
+// with err when set
+type counter struct {
+	calls int
+	err   error
+}
+
+func (c *counter) endpoint(_ context.Context, request interface{}) (interface{}, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &types.StringValue{Value: request.(*types.StringValue).Value}, nil
+}
+
+func withKey(key string) context.Context {
+	return GRPCToContext()(context.Background(), metadata.Pairs(Metadata, key))
+}
+
+func call(k *Keys, c *counter, ctx context.Context, value string) (interface{}, error) {
+	newResponse := func() proto.Message { return &types.StringValue{} }
+	return k.Middleware("Test", "Create", newResponse)(c.endpoint)(ctx, &types.StringValue{Value: value})
+}
+
+func TestMiddleware(t *testing.T) {
+	failure := errors.New("failure")
+	tests := []struct {
+		name string
+		// first runs before the call, defaulting to a call with key a and
+		// value a
+		first   func(*testing.T, *memoryStore, *counter)
+		ctx     context.Context
+		value   string
+		err     error
+		calls   int
+		replays bool
+	}{
+		{
+			name:  "no key",
+			first: func(*testing.T, *memoryStore, *counter) {},
+			ctx:   context.Background(),
+			value: "a",
+			calls: 1,
+		},
+		{
+			name:    "retry",
+			ctx:     withKey("a"),
+			value:   "a",
+			calls:   1,
+			replays: true,
+		},
+		{
+			name:  "other key",
+			ctx:   withKey("b"),
+			value: "a",
+			calls: 2,
+		},
+		{
+			name:  "other caller",
+			ctx:   auth.NewContext(withKey("a"), &auth.Principal{Kind: "apikey", ID: 1}),
+			value: "a",
+			calls: 2,
+		},
+		{
+			name:  "other request",
+			ctx:   withKey("a"),
+			value: "b",
+			err:   ErrKeyReused,
+			calls: 1,
+		},
+		{
+			name: "in progress",
+			first: func(t *testing.T, s *memoryStore, _ *counter) {
+				s.records["Test.Create:anonymous/a"] = &Record{Fingerprint: mustFingerprint(t, "a")}
+			},
+			ctx:   withKey("a"),
+			value: "a",
+			err:   ErrKeyInUse,
+		},
+		{
+			name: "retry of a failure",
+			first: func(t *testing.T, s *memoryStore, c *counter) {
+				c.err = failure
+				if _, err := call(NewKeys(s, Config{}, log.NewNopLogger()), c, withKey("a"), "a"); err != failure {
+					t.Fatalf("failing call error = %v, want %v", err, failure)
+				}
+				c.err = nil
+			},
+			ctx:   withKey("a"),
+			value: "a",
+			calls: 2,
+		},
+		{
+			name: "store failure",
+			first: func(_ *testing.T, s *memoryStore, _ *counter) {
+				s.err = failure
+			},
+			ctx:   withKey("a"),
+			value: "a",
+			err:   ErrStore,
+		},
+		{
+			name: "corrupt response",
+			first: func(t *testing.T, s *memoryStore, _ *counter) {
+				s.records["Test.Create:anonymous/a"] = &Record{Fingerprint: mustFingerprint(t, "a"), Response: []byte{0xff}}
+			},
+			ctx:   withKey("a"),
+			value: "a",
+			err:   ErrStore,
+		},
+		{
+			name:  "key too long",
+			first: func(*testing.T, *memoryStore, *counter) {},
+			ctx:   withKey(string(make([]byte, maxKeyLength+1))),
+			value: "a",
+			err:   ErrInvalidKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				store = newMemoryStore()
+				c     = &counter{}
+				keys  = NewKeys(store, Config{}, log.NewNopLogger())
+			)
+			if tt.first == nil {
+				if _, err := call(keys, c, withKey("a"), "a"); err != nil {
+					t.Fatalf("first call failed: %v", err)
+				}
+			} else {
+				tt.first(t, store, c)
+			}
+
+			stream := &headerStream{}
+			ctx := grpc.NewContextWithServerTransportStream(tt.ctx, stream)
+			response, err := call(keys, c, ctx, tt.value)
+			if err != tt.err {
+				t.Fatalf("call error = %v, want %v", err, tt.err)
+			}
+			if c.calls != tt.calls {
+				t.Errorf("endpoint called %d times, want %d", c.calls, tt.calls)
+			}
+			if err == nil && response.(*types.StringValue).Value != tt.value {
+				t.Errorf("response = %v, want %q", response, tt.value)
+			}
+			if replayed := len(stream.header.Get(ReplayedMetadata)) > 0; replayed != tt.replays {
+				t.Errorf("%s set = %v, want %v", ReplayedMetadata, replayed, tt.replays)
+			}
+		})
+	}
+}
+
+func TestMiddlewareTakeover(t *testing.T) {
+	failure := errors.New("failure")
+	for _, fails := range []bool{false, true} {
+		var (
+			store = newMemoryStore()
+			keys  = NewKeys(store, Config{}, log.NewNopLogger())
+			c     = &counter{}
+		)
+		if fails {
+			c.err = failure
+		}
+		// the lease of the request is over while it runs, and a retry takes
+		// its key over, still in progress when the request ends
+		takenOver := func(ctx context.Context, request interface{}) (interface{}, error) {
+			store.expire("Test.Create:anonymous", "a")
+			if _, err := store.Reserve(ctx, "Test.Create:anonymous", "a", "retry", mustFingerprint(t, "a"), 0, 0); err != nil {
+				t.Fatalf("Reserve failed: %v", err)
+			}
+			return c.endpoint(ctx, request)
+		}
+		newResponse := func() proto.Message { return &types.StringValue{} }
+		keys.Middleware("Test", "Create", newResponse)(takenOver)(withKey("a"), &types.StringValue{Value: "a"})
+
+		record, ok := store.records["Test.Create:anonymous/a"]
+		if !ok {
+			t.Fatalf("request failing %v released the reservation of the retry", fails)
+		}
+		if record.Response != nil {
+			t.Errorf("request failing %v completed the reservation of the retry", fails)
+		}
+	}
+}
+
+func mustFingerprint(t *testing.T, value string) string {
+	t.Helper()
+	fp, err := fingerprint("Test.Create", &types.StringValue{Value: value})
+	if err != nil {
+		t.Fatalf("fingerprint failed: %v", err)
+	}
+	return fp
+}
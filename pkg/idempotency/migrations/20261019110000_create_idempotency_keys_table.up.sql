@@ -0,0 +1,11 @@
+CREATE TABLE idempotency_keys (
+	scope       varchar(200) NOT NULL,
+	key         varchar(255) NOT NULL,
+	token       char(32) NOT NULL,
+	fingerprint char(64) NOT NULL,
+	response    bytea,
+	created_at  timestamp without time zone NOT NULL default timezone('utc', now()),
+	PRIMARY KEY (scope, key)
+);
+
+CREATE INDEX idempotency_keys_created_at_idx ON idempotency_keys (created_at);
@@ -0,0 +1,82 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// NewPostgresStore returns a Store keeping keys in the idempotency_keys
+// table, shared by every instance of the monolith
+func NewPostgresStore(db *sqlx.DB) Store {
+	return postgresStore{db: db}
+}
+
+type postgresStore struct {
+	db *sqlx.DB
+}
+
+func (s postgresStore) Reserve(ctx context.Context, scope, key, token, fingerprint string, window, lease time.Duration) (*Record, error) {
+	if _, err := s.db.ExecContext(ctx, sqlDeleteExpiredKey, scope, key, window.Seconds(), lease.Seconds()); err != nil {
+		return nil, err
+	}
+	res, err := s.db.ExecContext(ctx, sqlReserveKey, scope, key, token, fingerprint)
+	if err != nil {
+		return nil, err
+	}
+	if reserved, err := res.RowsAffected(); err != nil {
+		return nil, err
+	} else if reserved == 1 {
+		return nil, nil
+	}
+
+	var record Record
+	if err := s.db.QueryRowxContext(ctx, sqlFindKey, scope, key).Scan(&record.Fingerprint, &record.Response); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (s postgresStore) Complete(ctx context.Context, scope, key, token string, response []byte) error {
+	_, err := s.db.ExecContext(ctx, sqlCompleteKey, scope, key, token, response)
+	return err
+}
+
+func (s postgresStore) Release(ctx context.Context, scope, key, token string) error {
+	_, err := s.db.ExecContext(ctx, sqlReleaseKey, scope, key, token)
+	return err
+}
+
+func (s postgresStore) Purge(ctx context.Context, window time.Duration) (int64, error) {
+	res, err := s.db.ExecContext(ctx, sqlPurgeKeys, window.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// sqlDeleteExpiredKey deletes a key past its window ($3), or reserved past
+// its lease ($4) by a request that never completed
+const sqlDeleteExpiredKey = `
+	DELETE FROM idempotency_keys
+	WHERE scope = $1 AND key = $2 AND (
+		created_at < timezone('utc', now()) - $3 * interval '1 second' OR
+		(response IS NULL AND created_at < timezone('utc', now()) - $4 * interval '1 second'));`
+
+const sqlReserveKey = `
+	INSERT INTO idempotency_keys (scope, key, token, fingerprint)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (scope, key) DO NOTHING;`
+
+const sqlFindKey = "select fingerprint, response from idempotency_keys where scope = $1 and key = $2"
+
+// sqlCompleteKey and sqlReleaseKey only touch the reservation made with the
+// token ($3), not one taken over once its lease was over
+const sqlCompleteKey = "update idempotency_keys set response = $4 where scope = $1 and key = $2 and token = $3"
+
+const sqlReleaseKey = "delete from idempotency_keys where scope = $1 and key = $2 and token = $3"
+
+const sqlPurgeKeys = `
+	DELETE FROM idempotency_keys
+	WHERE created_at < timezone('utc', now()) - $1 * interval '1 second';`
@@ -0,0 +1,320 @@
+package listing
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxDepth bounds the nesting of parentheses and NOT in filters
+const maxDepth = 16
+
+// Filter translates a filter written in a subset of AIP-160 to a SQL
+// condition and its arguments. It supports:
+//
+//	comparisons  field = value, !=, <, <=, >, >=
+//	has          field:value, case insensitive, * matching any characters
+//	             for strings, e.g. name:"acme*"
+//	logic        a AND b, a OR b, NOT a, -a, parentheses and juxtaposition
+//	             for AND. As in AIP-160, OR binds tighter than AND.
+//
+// Values are either quoted, with \" and \\ escapes, or bare words.
+// Timestamps are RFC 3339 or dates, e.g. "2024-01-01".
+func (f Fields) Filter(filter string) (string, []interface{}, error) {
+	if strings.TrimSpace(filter) == "" {
+		return "", nil, nil
+	}
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return "", nil, err
+	}
+	p := &parser{fields: f, tokens: tokens}
+	where, err := p.expression(0)
+	if err != nil {
+		return "", nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return "", nil, invalidFilter("unexpected %s at position %d", t, t.pos)
+	}
+	return where, p.args, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of filter"
+	case tokenString:
+		return strconv.Quote(t.value)
+	}
+	return "'" + t.value + "'"
+}
+
+// isWordChar reports whether r may be part of a bare word: field names,
+// keywords, numbers and unquoted values
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.-*+", r)
+}
+
+func tokenize(filter string) ([]token, error) {
+	var tokens []token
+	runes := []rune(filter)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{tokenLParen, "(", i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{tokenRParen, ")", i})
+			i++
+		case r == '"':
+			start := i
+			var b strings.Builder
+			for i++; ; i++ {
+				if i >= len(runes) {
+					return nil, invalidFilter("unterminated string at position %d", start)
+				}
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+					b.WriteRune(runes[i])
+					continue
+				}
+				if runes[i] == '"' {
+					i++
+					break
+				}
+				b.WriteRune(runes[i])
+			}
+			tokens = append(tokens, token{tokenString, b.String(), start})
+		case r == '=' || r == ':':
+			tokens = append(tokens, token{tokenOperator, string(r), i})
+			i++
+		case r == '!' || r == '<' || r == '>':
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, invalidFilter("unexpected '!' at position %d, expected !=", i)
+			}
+			tokens = append(tokens, token{tokenOperator, op, i})
+			i += len(op)
+		case isWordChar(r):
+			start := i
+			for i < len(runes) && isWordChar(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{tokenWord, string(runes[start:i]), start})
+		default:
+			return nil, invalidFilter("unexpected character %q at position %d", r, i)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type parser struct {
+	fields Fields
+	tokens []token
+	pos    int
+	args   []interface{}
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) keyword(word string) bool {
+	if t := p.peek(); t.kind == tokenWord && t.value == word {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// expression: sequence {[AND] sequence}
+func (p *parser) expression(depth int) (string, error) {
+	var parts []string
+	for {
+		part, err := p.sequence(depth)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, part)
+		if p.keyword("AND") {
+			continue
+		}
+		if t := p.peek(); t.kind == tokenEOF || t.kind == tokenRParen {
+			break
+		}
+	}
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+	return "(" + strings.Join(parts, " AND ") + ")", nil
+}
+
+// sequence: term {OR term}
+func (p *parser) sequence(depth int) (string, error) {
+	var parts []string
+	for {
+		part, err := p.term(depth)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, part)
+		if !p.keyword("OR") {
+			break
+		}
+	}
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+	return "(" + strings.Join(parts, " OR ") + ")", nil
+}
+
+// term: [NOT | -] simple, simple: ( expression ) | restriction
+func (p *parser) term(depth int) (string, error) {
+	if depth > maxDepth {
+		return "", invalidFilter("filter nested deeper than %d levels", maxDepth)
+	}
+	if p.keyword("NOT") {
+		inner, err := p.term(depth + 1)
+		if err != nil {
+			return "", err
+		}
+		return negate(inner), nil
+	}
+	if t := p.peek(); t.kind == tokenWord && strings.HasPrefix(t.value, "-") && len(t.value) > 1 {
+		// -field:value negates the restriction
+		p.tokens[p.pos].value = t.value[1:]
+		inner, err := p.term(depth + 1)
+		if err != nil {
+			return "", err
+		}
+		return negate(inner), nil
+	}
+
+	if t := p.peek(); t.kind == tokenLParen {
+		p.next()
+		inner, err := p.expression(depth + 1)
+		if err != nil {
+			return "", err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return "", invalidFilter("expected ')' at position %d, got %s", t.pos, t)
+		}
+		return "(" + inner + ")", nil
+	}
+	return p.restriction()
+}
+
+// restriction: field operator value
+func (p *parser) restriction() (string, error) {
+	name := p.next()
+	if name.kind != tokenWord {
+		return "", invalidFilter("expected a field at position %d, got %s", name.pos, name)
+	}
+	field, ok := p.fields[name.value]
+	if !ok {
+		return "", invalidFilter("unknown field %q at position %d, filterable fields are %s", name.value, name.pos, p.fields.names())
+	}
+	op := p.next()
+	if op.kind != tokenOperator {
+		return "", invalidFilter("expected an operator after %q at position %d, got %s", name.value, op.pos, op)
+	}
+	value := p.next()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return "", invalidFilter("expected a value after %q at position %d, got %s", name.value+op.value, value.pos, value)
+	}
+
+	arg, err := parseValue(field.Type, value.value)
+	if err != nil {
+		return "", invalidFilter("invalid value %s of field %q at position %d: %s", value, name.value, value.pos, err)
+	}
+
+	sqlOp := op.value
+	switch {
+	case op.value == "!=":
+		sqlOp = "<>"
+	case op.value == ":" && field.Type == String:
+		// has on strings is a case insensitive match, * matching anything
+		sqlOp = "ILIKE"
+		arg = likePattern(value.value)
+	case op.value == ":":
+		sqlOp = "="
+	}
+	p.args = append(p.args, arg)
+	return field.Column + " " + sqlOp + " ?", nil
+}
+
+func negate(condition string) string {
+	if strings.HasPrefix(condition, "(") {
+		return "NOT " + condition
+	}
+	return "NOT (" + condition + ")"
+}
+
+func parseValue(typ Type, value string) (interface{}, error) {
+	switch typ {
+	case Int:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, errExpected("an integer")
+		}
+		return n, nil
+	case Timestamp:
+		if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			// timestamps are stored in UTC without a time zone
+			return t.UTC(), nil
+		}
+		if t, err := time.Parse("2006-01-02", value); err == nil {
+			return t, nil
+		}
+		return nil, errExpected("an RFC 3339 timestamp or a date")
+	}
+	// Postgres rejects text it can't store
+	if !utf8.ValidString(value) || strings.IndexByte(value, 0) >= 0 {
+		return nil, errExpected("valid UTF-8 text")
+	}
+	return value, nil
+}
+
+type errExpected string
+
+func (e errExpected) Error() string {
+	return "expected " + string(e)
+}
+
+// likePattern escapes the wildcards of LIKE in value and turns its * into %
+func likePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`, `*`, `%`).Replace(value)
+}
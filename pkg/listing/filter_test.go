@@ -0,0 +1,134 @@
+package listing
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+)
+
+var testFields = Fields{
+	"id":         {Column: "id", Type: Int},
+	"name":       {Column: "name", Type: String},
+	"created_at": {Column: "created_at", Type: Timestamp},
+}
+
+func TestFilter(t *testing.T) {
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		filter string
+		where  string
+		args   []interface{}
+	}{
+		{"empty", "  ", "", nil},
+		{"equal", "id = 3", "id = ?", []interface{}{int64(3)}},
+		{"not equal", "id != 3", "id <> ?", []interface{}{int64(3)}},
+		{"comparisons", "id >= 3 AND id < 9", "(id >= ? AND id < ?)", []interface{}{int64(3), int64(9)}},
+		{"has on strings", "name:acme", "name ILIKE ?", []interface{}{"acme"}},
+		{"has on other types", "id:3", "id = ?", []interface{}{int64(3)}},
+		{"date", "created_at > 2024-01-01", "created_at > ?", []interface{}{date}},
+		{"timestamp", `created_at > "2024-01-01T00:00:00Z"`, "created_at > ?", []interface{}{date}},
+		{"timestamp with an offset", `created_at > "2024-01-01T02:00:00+02:00"`, "created_at > ?", []interface{}{date}},
+		{"implicit AND", "id = 1 name = a", "(id = ? AND name = ?)", []interface{}{int64(1), "a"}},
+		{
+			"OR binds tighter than AND",
+			"id = 1 AND id = 2 OR id = 3",
+			"(id = ? AND (id = ? OR id = ?))",
+			[]interface{}{int64(1), int64(2), int64(3)},
+		},
+		{
+			"parentheses",
+			"(id = 1 AND id = 2) OR id = 3",
+			"(((id = ? AND id = ?)) OR id = ?)",
+			[]interface{}{int64(1), int64(2), int64(3)},
+		},
+		{"NOT", "NOT id = 1", "NOT (id = ?)", []interface{}{int64(1)}},
+		{"minus", "-name:a", "NOT (name ILIKE ?)", []interface{}{"a"}},
+		{"NOT of a group", "NOT (id = 1 OR id = 2)", "NOT ((id = ? OR id = ?))", []interface{}{int64(1), int64(2)}},
+		{"NOT binds tighter than OR", "NOT id = 1 OR id = 2", "(NOT (id = ?) OR id = ?)", []interface{}{int64(1), int64(2)}},
+		{"quoted keywords are values", `name = "AND"`, "name = ?", []interface{}{"AND"}},
+		{"quote escapes", `name = "a \"b\" \\c"`, "name = ?", []interface{}{`a "b" \c`}},
+		{"negative numbers are values", "id > -1", "id > ?", []interface{}{int64(-1)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args, err := testFields.Filter(tt.filter)
+			if err != nil {
+				t.Fatalf("Filter(%q) failed: %v", tt.filter, err)
+			}
+			if where != tt.where {
+				t.Errorf("Filter(%q) where = %q, want %q", tt.filter, where, tt.where)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("Filter(%q) args = %#v, want %#v", tt.filter, args, tt.args)
+			}
+		})
+	}
+}
+
+func TestFilterLikeEscaping(t *testing.T) {
+	tests := []struct {
+		filter  string
+		pattern string
+	}{
+		{`name:acme`, `acme`},
+		{`name:acme*`, `acme%`},
+		{`name:"*co*"`, `%co%`},
+		{`name:"100%"`, `100\%`},
+		{`name:a_b`, `a\_b`},
+		{`name:"a\\b"`, `a\\b`},
+	}
+	for _, tt := range tests {
+		_, args, err := testFields.Filter(tt.filter)
+		if err != nil {
+			t.Fatalf("Filter(%q) failed: %v", tt.filter, err)
+		}
+		if len(args) != 1 || args[0] != tt.pattern {
+			t.Errorf("Filter(%q) args = %#v, want [%q]", tt.filter, args, tt.pattern)
+		}
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+	}{
+		{"unknown field", "email = a"},
+		{"missing operator", "name a"},
+		{"missing value", "name ="},
+		{"bare bang", "name ! a"},
+		{"unterminated string", `name = "a`},
+		{"unbalanced parenthesis", "(id = 1"},
+		{"stray parenthesis", "id = 1)"},
+		{"invalid integer", "id = a"},
+		{"invalid timestamp", "created_at > yesterday"},
+		{"NUL in text", "name = \"a\x00b\""},
+		{"unexpected character", "name = a; drop"},
+		{"dangling OR", "id = 1 OR"},
+		{"nested too deep", strings.Repeat("(", maxDepth+1) + "id = 1" + strings.Repeat(")", maxDepth+1)},
+		{"negated too deep", strings.Repeat("NOT ", maxDepth+1) + "id = 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := testFields.Filter(tt.filter)
+			e, ok := err.(*apierror.Error)
+			if !ok {
+				t.Fatalf("Filter(%q) error = %v, want an *apierror.Error", tt.filter, err)
+			}
+			if e.Reason != ReasonInvalidFilter || len(e.Fields) != 1 || e.Fields[0].Field != "filter" {
+				t.Errorf("Filter(%q) error = %+v, want %s on filter", tt.filter, e, ReasonInvalidFilter)
+			}
+		})
+	}
+}
+
+func TestFilterMaxDepth(t *testing.T) {
+	filter := strings.Repeat("(", maxDepth) + "id = 1" + strings.Repeat(")", maxDepth)
+	if _, _, err := testFields.Filter(filter); err != nil {
+		t.Errorf("Filter nested %d levels failed: %v", maxDepth, err)
+	}
+}
@@ -0,0 +1,213 @@
+package listing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+)
+
+// Listing error reasons
+const (
+	ReasonInvalidFilter  = "FILTER_INVALID"
+	ReasonInvalidOrderBy = "ORDER_BY_INVALID"
+)
+
+// Listing errors, returned with a violation of the filter or order_by field
+// describing the problem
+var (
+	ErrInvalidFilter  = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonInvalidFilter, "invalid filter")
+	ErrInvalidOrderBy = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonInvalidOrderBy, "invalid order_by")
+)
+
+// Type is the type of a field, it decides how filter values are read
+type Type int
+
+// Field types
+const (
+	String Type = iota
+	Int
+	Timestamp
+)
+
+// Field is a field a listing can be filtered and ordered by
+type Field struct {
+	// Column is the SQL expression the field maps to. It is written to
+	// statements as is, so it must never come from a request.
+	Column string
+	Type   Type
+}
+
+// Fields is the whitelist of fields of a listing, by proto name. It must
+// include id, which breaks ties between results.
+type Fields map[string]Field
+
+// Query is a filter and order_by translated to SQL. Conditions use ?
+// placeholders, to be rebound for the driver.
+type Query struct {
+	// Where is the condition of the filter, empty without one
+	Where string
+	Args  []interface{}
+	Order []Order
+
+	filter, orderBy string
+}
+
+// Parse translates a filter and order_by to a Query, failing with
+// ErrInvalidFilter or ErrInvalidOrderBy on anything outside fields
+func (f Fields) Parse(filter, orderBy string) (Query, error) {
+	q := Query{filter: filter, orderBy: orderBy}
+	var err error
+	if q.Where, q.Args, err = f.Filter(filter); err != nil {
+		return Query{}, err
+	}
+	if q.Order, err = f.OrderBy(orderBy); err != nil {
+		return Query{}, err
+	}
+	return q, nil
+}
+
+// And returns a copy of q also requiring condition, e.g. a condition set by
+// the service rather than the filter. Page tokens are tied to it too.
+func (q Query) And(condition string, args ...interface{}) Query {
+	if q.Where == "" {
+		q.Where = condition
+	} else {
+		q.Where = "(" + q.Where + ") AND " + condition
+	}
+	q.Args = append(append([]interface{}(nil), q.Args...), args...)
+	q.filter += "\x00" + condition
+	return q
+}
+
+// Select completes base, a SELECT statement without WHERE clause, with the
+// filter of q, the keyset condition of the results after the cursor keys,
+// the order of q, an offset and a limit
+func (q Query) Select(base string, after []string, offset, limit int) (string, []interface{}) {
+	var conditions []string
+	args := append([]interface{}(nil), q.Args...)
+	if q.Where != "" {
+		conditions = append(conditions, q.Where)
+	}
+	if len(after) > 0 {
+		seek, seekArgs := q.seek(after)
+		conditions = append(conditions, seek)
+		args = append(args, seekArgs...)
+	}
+
+	var b strings.Builder
+	b.WriteString(base)
+	if len(conditions) > 0 {
+		b.WriteString(" WHERE ")
+		b.WriteString(strings.Join(conditions, " AND "))
+	}
+	order := make([]string, len(q.Order))
+	for i, o := range q.Order {
+		order[i] = o.Column
+		if o.Desc {
+			order[i] += " DESC"
+		}
+	}
+	b.WriteString(" ORDER BY ")
+	b.WriteString(strings.Join(order, ", "))
+	b.WriteString(" LIMIT ?")
+	args = append(args, limit)
+	if offset > 0 {
+		b.WriteString(" OFFSET ?")
+		args = append(args, offset)
+	}
+	return b.String(), args
+}
+
+// Count completes base, a SELECT count statement without WHERE clause, with
+// the filter of q
+func (q Query) Count(base string) (string, []interface{}) {
+	if q.Where == "" {
+		return base, nil
+	}
+	return base + " WHERE " + q.Where, q.Args
+}
+
+// seek returns the condition of the rows following the one with the given
+// keys in the order of q: (a > ?) OR (a = ? AND b > ?) OR ...
+func (q Query) seek(after []string) (string, []interface{}) {
+	var (
+		alternatives []string
+		args         []interface{}
+	)
+	for i, o := range q.Order {
+		var parts []string
+		for _, prev := range q.Order[:i] {
+			parts = append(parts, prev.Column+" = ?")
+		}
+		op := " > ?"
+		if o.Desc {
+			op = " < ?"
+		}
+		parts = append(parts, o.Column+op)
+		alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+		for _, key := range after[:i+1] {
+			args = append(args, key)
+		}
+	}
+	return "(" + strings.Join(alternatives, " OR ") + ")", args
+}
+
+// Cursor is the content of page tokens of filtered listings: the keys of the
+// last result of a page, in the order of the query, and the query it came
+// from
+type Cursor struct {
+	Query string   `json:"q"`
+	Keys  []string `json:"k"`
+}
+
+// Cursor returns the cursor of a result, key returning the value of each
+// field of the order of q as its text representation in SQL
+func (q Query) Cursor(key func(field string) string) Cursor {
+	c := Cursor{Query: q.fingerprint()}
+	for _, o := range q.Order {
+		c.Keys = append(c.Keys, key(o.Field))
+	}
+	return c
+}
+
+// Valid reports whether c was issued for q. Page tokens aren't signed, so the
+// keys are also checked to be values of the type of their field, which
+// they're bound as.
+func (q Query) Valid(c Cursor) bool {
+	if c.Query != q.fingerprint() || len(c.Keys) != len(q.Order) {
+		return false
+	}
+	for i, o := range q.Order {
+		if _, err := parseValue(o.Type, c.Keys[i]); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (q Query) fingerprint() string {
+	sum := sha256.Sum256([]byte(q.filter + "\x00" + q.orderBy))
+	return hex.EncodeToString(sum[:8])
+}
+
+func (f Fields) names() string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func invalidFilter(format string, args ...interface{}) error {
+	return ErrInvalidFilter.WithFields(apierror.FieldViolation{Field: "filter", Description: fmt.Sprintf(format, args...)})
+}
+
+func invalidOrderBy(format string, args ...interface{}) error {
+	return ErrInvalidOrderBy.WithFields(apierror.FieldViolation{Field: "order_by", Description: fmt.Sprintf(format, args...)})
+}
@@ -0,0 +1,115 @@
+package listing
+
+import (
+	"reflect"
+	"testing"
+)
+
+func mustParse(t *testing.T, filter, orderBy string) Query {
+	t.Helper()
+	q, err := testFields.Parse(filter, orderBy)
+	if err != nil {
+		t.Fatalf("Parse(%q, %q) failed: %v", filter, orderBy, err)
+	}
+	return q
+}
+
+func TestSelect(t *testing.T) {
+	const base = "SELECT * FROM companies"
+	tests := []struct {
+		name    string
+		filter  string
+		orderBy string
+		after   []string
+		offset  int
+		sql     string
+		args    []interface{}
+	}{
+		{
+			name: "first page",
+			sql:  base + " ORDER BY id LIMIT ?",
+			args: []interface{}{10},
+		},
+		{
+			name:   "offset",
+			offset: 20,
+			sql:    base + " ORDER BY id LIMIT ? OFFSET ?",
+			args:   []interface{}{10, 20},
+		},
+		{
+			name:  "after id",
+			after: []string{"7"},
+			sql:   base + " WHERE ((id > ?)) ORDER BY id LIMIT ?",
+			args:  []interface{}{"7", 10},
+		},
+		{
+			name:    "after several keys",
+			orderBy: "name, created_at desc",
+			after:   []string{"acme", "2024-01-01", "7"},
+			sql: base + " WHERE ((name > ?) OR (name = ? AND created_at < ?)" +
+				" OR (name = ? AND created_at = ? AND id > ?))" +
+				" ORDER BY name, created_at DESC, id LIMIT ?",
+			args: []interface{}{"acme", "acme", "2024-01-01", "acme", "2024-01-01", "7", 10},
+		},
+		{
+			name:    "filter and keyset",
+			filter:  "name:a*",
+			orderBy: "id desc",
+			after:   []string{"7"},
+			sql:     base + " WHERE name ILIKE ? AND ((id < ?)) ORDER BY id DESC LIMIT ?",
+			args:    []interface{}{"a%", "7", 10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := mustParse(t, tt.filter, tt.orderBy)
+			sql, args := q.Select(base, tt.after, tt.offset, 10)
+			if sql != tt.sql {
+				t.Errorf("Select() sql = %q, want %q", sql, tt.sql)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("Select() args = %#v, want %#v", args, tt.args)
+			}
+		})
+	}
+}
+
+func TestAnd(t *testing.T) {
+	q := mustParse(t, "id = 1 OR id = 2", "").And("deleted_at IS NULL")
+	if want := "((id = ? OR id = ?)) AND deleted_at IS NULL"; q.Where != want {
+		t.Errorf("And() where = %q, want %q", q.Where, want)
+	}
+	if mustParse(t, "id = 1 OR id = 2", "").fingerprint() == q.fingerprint() {
+		t.Error("And() kept the fingerprint of the query")
+	}
+}
+
+func TestValid(t *testing.T) {
+	q := mustParse(t, "name:a*", "created_at desc")
+	valid := q.Cursor(func(field string) string {
+		return map[string]string{"created_at": "2024-01-01T00:00:00Z", "id": "7"}[field]
+	})
+	tests := []struct {
+		name   string
+		cursor Cursor
+		valid  bool
+	}{
+		{"issued by the query", valid, true},
+		{"other query", Cursor{Query: mustParse(t, "name:b*", "created_at desc").fingerprint(), Keys: valid.Keys}, false},
+		{"missing key", Cursor{Query: valid.Query, Keys: valid.Keys[:1]}, false},
+		{"forged integer", Cursor{Query: valid.Query, Keys: []string{"2024-01-01T00:00:00Z", "7 OR 1=1"}}, false},
+		{"forged timestamp", Cursor{Query: valid.Query, Keys: []string{"yesterday", "7"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := q.Valid(tt.cursor); got != tt.valid {
+				t.Errorf("Valid(%+v) = %v, want %v", tt.cursor, got, tt.valid)
+			}
+		})
+	}
+
+	text := mustParse(t, "", "name")
+	if text.Valid(Cursor{Query: text.fingerprint(), Keys: []string{"a\x00", "7"}}) {
+		t.Error("Valid() accepted a key with a NUL byte")
+	}
+}
@@ -0,0 +1,53 @@
+package listing
+
+import "strings"
+
+// Order is a field results are sorted by
+type Order struct {
+	Field  string
+	Column string
+	Type   Type
+	Desc   bool
+}
+
+// OrderBy translates an AIP-132 order_by, a comma separated list of fields
+// each optionally followed by asc or desc, e.g. "name desc, created_at".
+// Results are sorted by id last, so every result has a distinct position.
+func (f Fields) OrderBy(orderBy string) ([]Order, error) {
+	var (
+		order []Order
+		seen  = map[string]bool{}
+	)
+	if strings.TrimSpace(orderBy) != "" {
+		for _, item := range strings.Split(orderBy, ",") {
+			words := strings.Fields(item)
+			if len(words) == 0 || len(words) > 2 {
+				return nil, invalidOrderBy("expected a field optionally followed by asc or desc, got %q", strings.TrimSpace(item))
+			}
+			field, ok := f[words[0]]
+			if !ok {
+				return nil, invalidOrderBy("unknown field %q, results can be ordered by %s", words[0], f.names())
+			}
+			if seen[words[0]] {
+				return nil, invalidOrderBy("field %q is listed twice", words[0])
+			}
+			seen[words[0]] = true
+
+			o := Order{Field: words[0], Column: field.Column, Type: field.Type}
+			if len(words) == 2 {
+				switch strings.ToLower(words[1]) {
+				case "asc":
+				case "desc":
+					o.Desc = true
+				default:
+					return nil, invalidOrderBy("unknown direction %q of field %q, expected asc or desc", words[1], words[0])
+				}
+			}
+			order = append(order, o)
+		}
+	}
+	if !seen["id"] {
+		order = append(order, Order{Field: "id", Column: f["id"].Column, Type: f["id"].Type})
+	}
+	return order, nil
+}
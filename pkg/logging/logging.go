@@ -0,0 +1,162 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+	"github.com/nathanows/elegant-monolith/pkg/redact"
+)
+
+// Output formats
+const (
+	FormatJSON   = "json"
+	FormatLogfmt = "logfmt"
+)
+
+// Config configures the application loggers
+type Config struct {
+	// Format is json, the default, or logfmt
+	Format string
+	// Level is the minimum level logged: debug, info, the default, warn or
+	// error. Lines without a level are always logged.
+	Level string
+	// Modules overrides Level for the loggers of individual modules, keyed by
+	// module name
+	Modules map[string]string
+	// SampleEvery keeps one in SampleEvery debug and info lines of each
+	// module, the per call success lines. Lines are counted once they pass
+	// the level of the module, and lines carrying an err are always kept.
+	// Zero or one keeps them all.
+	SampleEvery int
+}
+
+// Loggers hands out loggers sharing one output, filtered according to Config
+type Loggers struct {
+	output  log.Logger
+	config  Config
+	options map[string]level.Option
+
+	mtx      sync.Mutex
+	samplers map[string]*sampler
+}
+
+// New returns Loggers writing to w
+func New(w io.Writer, config Config) (*Loggers, error) {
+	l := &Loggers{config: config, options: map[string]level.Option{}, samplers: map[string]*sampler{}}
+
+	switch strings.ToLower(config.Format) {
+	case "", FormatJSON:
+		l.output = log.NewJSONLogger(log.NewSyncWriter(w))
+	case FormatLogfmt:
+		l.output = log.NewLogfmtLogger(log.NewSyncWriter(w))
+	default:
+		return nil, fmt.Errorf("logging: unknown format %q", config.Format)
+	}
+	l.output = redact.Logger(l.output)
+
+	var err error
+	if l.options[""], err = parseLevel(config.Level); err != nil {
+		return nil, err
+	}
+	for module, lvl := range config.Modules {
+		if l.options[module], err = parseLevel(lvl); err != nil {
+			return nil, fmt.Errorf("logging: module %s: %s", module, err)
+		}
+	}
+	return l, nil
+}
+
+// Root returns the logger for everything outside of the modules
+func (l *Loggers) Root() log.Logger {
+	logger := level.NewFilter(l.output, l.options[""])
+	return log.With(logger, "ts", log.DefaultTimestampUTC, "caller", log.DefaultCaller)
+}
+
+// Module returns the logger of the named module, tagging its lines with the
+// module name
+func (l *Loggers) Module(name string) log.Logger {
+	option, ok := l.options[name]
+	if !ok {
+		option = l.options[""]
+	}
+
+	output := l.output
+	if l.config.SampleEvery > 1 {
+		output = l.sampler(name)
+	}
+	logger := level.NewFilter(output, option)
+	return log.With(logger, "ts", log.DefaultTimestampUTC, "caller", log.DefaultCaller, "module", name)
+}
+
+// sampler returns the sampler of the named module, shared by all of its
+// loggers so that modules don't decide which lines of each other are kept
+func (l *Loggers) sampler(name string) *sampler {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	s, ok := l.samplers[name]
+	if !ok {
+		s = &sampler{next: l.output, every: uint64(l.config.SampleEvery)}
+		l.samplers[name] = s
+	}
+	return s
+}
+
+// ErrorLevel returns logger at the level err is logged at, as access logs
+// do: error for errors of the server, warn for errors of the request such as
+// invalid arguments or missing resources. Both stand apart from the info
+// lines of successful calls.
+func ErrorLevel(logger log.Logger, err error) log.Logger {
+	if apierror.FromError(err).HTTPStatus() >= http.StatusInternalServerError {
+		return level.Error(logger)
+	}
+	return level.Warn(logger)
+}
+
+func parseLevel(lvl string) (level.Option, error) {
+	switch strings.ToLower(lvl) {
+	case "debug":
+		return level.AllowDebug(), nil
+	case "", "info":
+		return level.AllowInfo(), nil
+	case "warn":
+		return level.AllowWarn(), nil
+	case "error":
+		return level.AllowError(), nil
+	default:
+		return nil, fmt.Errorf("logging: unknown level %q", lvl)
+	}
+}
+
+// sampler logs the first of every N debug and info lines and drops the
+// others, N being every, e.g. one line in ten when every is 10. Lines of any
+// other level, and lines carrying an err, are always logged.
+type sampler struct {
+	next  log.Logger
+	every uint64
+	count uint64
+}
+
+func (s *sampler) Log(keyvals ...interface{}) error {
+	sampled := false
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		switch keyvals[i] {
+		case "err":
+			return s.next.Log(keyvals...)
+		case level.Key():
+			v := keyvals[i+1]
+			sampled = v == level.DebugValue() || v == level.InfoValue()
+		}
+	}
+	if sampled && atomic.AddUint64(&s.count, 1)%s.every != 1 {
+		return nil
+	}
+	return s.next.Log(keyvals...)
+}
@@ -0,0 +1,71 @@
+package logging
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log/level"
+
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+)
+
+func TestSampling(t *testing.T) {
+	var b bytes.Buffer
+	loggers, err := New(&b, Config{Format: FormatLogfmt, SampleEvery: 2, Modules: map[string]string{"quiet": "warn"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		a     = loggers.Module("a")
+		again = loggers.Module("a")
+		other = loggers.Module("b")
+		quiet = loggers.Module("quiet")
+	)
+	for i := 0; i < 4; i++ {
+		// filtered out by the level of the module, not counted
+		level.Info(quiet).Log("msg", "quiet")
+	}
+	level.Info(a).Log("msg", "a1")
+	level.Info(again).Log("msg", "a2")
+	level.Info(other).Log("msg", "b1")
+	level.Info(a).Log("msg", "a3")
+	level.Info(a).Log("msg", "a4", "err", errors.New("failure"))
+	level.Warn(a).Log("msg", "a5")
+	level.Info(quiet).Log("msg", "quiet")
+	level.Warn(quiet).Log("msg", "q1")
+
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(b.String()), "\n") {
+		got = append(got, line[strings.Index(line, "msg=")+len("msg="):][:2])
+	}
+	want := []string{"a1", "b1", "a3", "a4", "a5", "q1"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("logged %v, want %v", got, want)
+	}
+}
+
+func TestErrorLevel(t *testing.T) {
+	tests := []struct {
+		err   error
+		level string
+	}{
+		{errors.New("failure"), "error"},
+		{apierror.New(codespb.Code_INTERNAL, "INTERNAL", "failure"), "error"},
+		{apierror.New(codespb.Code_NOT_FOUND, "NOT_FOUND", "missing"), "warn"},
+		{apierror.New(codespb.Code_INVALID_ARGUMENT, "INVALID", "invalid"), "warn"},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		loggers, err := New(&b, Config{Format: FormatLogfmt, SampleEvery: 10})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ErrorLevel(loggers.Module("a"), tt.err).Log("err", tt.err)
+		if !strings.Contains(b.String(), "level="+tt.level+" ") {
+			t.Errorf("%v logged as %q, want level %s", tt.err, b.String(), tt.level)
+		}
+	}
+}
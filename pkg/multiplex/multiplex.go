@@ -0,0 +1,30 @@
+package multiplex
+
+import (
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+// Handler serves native gRPC calls with grpcServer and every other request,
+// REST and gRPC-Web included, with httpAPI, so both can share a single port.
+// HTTP/2 is accepted over cleartext (h2c), either with prior knowledge as gRPC
+// clients do or through an HTTP/1.1 upgrade.
+func Handler(grpcServer http.Handler, httpAPI http.Handler) http.Handler {
+	return h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if IsGRPCRequest(r) {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		httpAPI.ServeHTTP(w, r)
+	}), &http2.Server{})
+}
+
+// IsGRPCRequest reports whether r is a native gRPC call, made over HTTP/2 with
+// an application/grpc content type. gRPC-Web calls don't qualify.
+func IsGRPCRequest(r *http.Request) bool {
+	contentType := r.Header.Get("Content-Type")
+	return r.ProtoMajor == 2 && (contentType == "application/grpc" || strings.HasPrefix(contentType, "application/grpc+"))
+}
@@ -0,0 +1,432 @@
+package openapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	annotations "github.com/nathanows/elegant-monolith/_protos/google/api"
+	optionspb "github.com/nathanows/elegant-monolith/_protos/options"
+	"github.com/nathanows/elegant-monolith/pkg/protodesc"
+)
+
+// ErrorSchema is the name of the schema describing error responses
+const ErrorSchema = "Error"
+
+// maxQueryDepth bounds how deep nested messages are flattened into query
+// parameters, recursive messages would otherwise never end
+const maxQueryDepth = 4
+
+// codeNames lists the names of the codes errors may carry, in code order
+func codeNames() []string {
+	names := make([]string, len(codespb.Code_name))
+	for i := range names {
+		names[i] = codespb.Code_name[int32(i)]
+	}
+	return names
+}
+
+// Service identifies a gRPC service by the proto file declaring it, e.g.
+// "companyusers/companyusers.proto", and its fully qualified name, e.g.
+// "companyusers.CompanySvc"
+type Service struct {
+	File string
+	Name string
+}
+
+// Generate builds the OpenAPI document of the REST mapping of the given
+// services from the google.api.http annotations of their methods. Message
+// schemas follow the proto3 JSON mapping the HTTP transport speaks.
+func Generate(info Info, services ...Service) (*Document, error) {
+	g := &generator{
+		doc: &Document{
+			OpenAPI: Version,
+			Info:    info,
+			Paths:   map[string]*PathItem{},
+			Components: Components{Schemas: map[string]*Schema{
+				ErrorSchema: {
+					Type: "object",
+					Properties: map[string]*Schema{
+						"error":  {Type: "string"},
+						"code":   {Type: "string", Enum: codeNames()},
+						"reason": {Type: "string"},
+						"errors": {
+							Type: "array",
+							Items: &Schema{
+								Type: "object",
+								Properties: map[string]*Schema{
+									"field":       {Type: "string"},
+									"rule":        {Type: "string"},
+									"description": {Type: "string"},
+								},
+							},
+						},
+					},
+					Required: []string{"error", "code", "reason"},
+				},
+			}},
+		},
+		messages: map[string]*descriptor.DescriptorProto{},
+		enums:    map[string]*descriptor.EnumDescriptorProto{},
+	}
+
+	indexed := map[string]bool{}
+	for _, service := range services {
+		if indexed[service.File] {
+			continue
+		}
+		fd, err := protodesc.File(service.File)
+		if err != nil {
+			return nil, err
+		}
+		g.index("."+fd.GetPackage(), fd.GetMessageType(), fd.GetEnumType())
+		indexed[service.File] = true
+	}
+
+	for _, service := range services {
+		sd, err := protodesc.Service(service.File, service.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, md := range sd.GetMethod() {
+			rule := protodesc.HTTPRule(md)
+			if rule == nil {
+				continue
+			}
+			bindings := append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...)
+			for i, binding := range bindings {
+				operationID := sd.GetName() + "_" + md.GetName()
+				if i > 0 {
+					operationID += strconv.Itoa(i)
+				}
+				if err := g.addOperation(operationID, sd.GetName(), md, binding); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return g.doc, nil
+}
+
+type generator struct {
+	doc      *Document
+	messages map[string]*descriptor.DescriptorProto
+	enums    map[string]*descriptor.EnumDescriptorProto
+}
+
+// index records messages and enums by the fully qualified name descriptors
+// refer to them with, e.g. ".companyusers.Company"
+func (g *generator) index(scope string, messages []*descriptor.DescriptorProto, enums []*descriptor.EnumDescriptorProto) {
+	for _, ed := range enums {
+		g.enums[scope+"."+ed.GetName()] = ed
+	}
+	for _, md := range messages {
+		name := scope + "." + md.GetName()
+		g.messages[name] = md
+		g.index(name, md.GetNestedType(), md.GetEnumType())
+	}
+}
+
+func (g *generator) addOperation(operationID, tag string, md *descriptor.MethodDescriptorProto, rule *annotations.HttpRule) error {
+	method, template := protodesc.HTTPPattern(rule)
+	input, ok := g.messages[md.GetInputType()]
+	if !ok {
+		return fmt.Errorf("openapi: unknown input type %s of %s", md.GetInputType(), operationID)
+	}
+
+	op := &Operation{
+		OperationID: operationID,
+		Tags:        []string{tag},
+		Responses: map[string]*Response{
+			"default": {
+				Description: "An error response.",
+				Content:     jsonContent(ref(ErrorSchema)),
+			},
+		},
+	}
+
+	path, pathFields := parseTemplate(template)
+	bound := map[string]bool{}
+	for _, fieldPath := range pathFields {
+		field, err := g.lookupField(input, fieldPath)
+		if err != nil {
+			return fmt.Errorf("openapi: %s: %s", operationID, err)
+		}
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:     fieldPath,
+			In:       "path",
+			Required: true,
+			Schema:   g.fieldSchema(field),
+		})
+		bound[fieldPath] = true
+	}
+
+	switch body := rule.GetBody(); body {
+	case "":
+		op.Parameters = append(op.Parameters, g.queryParameters(input, "", bound, 0)...)
+	case "*":
+		op.RequestBody = &RequestBody{Required: true, Content: jsonContent(g.messageSchema(md.GetInputType()))}
+	default:
+		field, err := g.lookupField(input, body)
+		if err != nil {
+			return fmt.Errorf("openapi: %s: %s", operationID, err)
+		}
+		bound[body] = true
+		op.Parameters = append(op.Parameters, g.queryParameters(input, "", bound, 0)...)
+		op.RequestBody = &RequestBody{Required: true, Content: jsonContent(g.fieldSchema(field))}
+	}
+
+	response := g.messageSchema(md.GetOutputType())
+	if responseBody := rule.GetResponseBody(); responseBody != "" {
+		output, ok := g.messages[md.GetOutputType()]
+		if !ok {
+			return fmt.Errorf("openapi: unknown output type %s of %s", md.GetOutputType(), operationID)
+		}
+		field, err := g.lookupField(output, responseBody)
+		if err != nil {
+			return fmt.Errorf("openapi: %s: %s", operationID, err)
+		}
+		response = g.fieldSchema(field)
+	}
+	op.Responses["200"] = &Response{
+		Description: "A successful response.",
+		Content:     jsonContent(response),
+	}
+
+	item, ok := g.doc.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		g.doc.Paths[path] = item
+	}
+	switch method {
+	case "GET":
+		item.Get = op
+	case "PUT":
+		item.Put = op
+	case "POST":
+		item.Post = op
+	case "DELETE":
+		item.Delete = op
+	case "PATCH":
+		item.Patch = op
+	default:
+		return fmt.Errorf("openapi: %s: unsupported HTTP method %q", operationID, method)
+	}
+	return nil
+}
+
+// parseTemplate turns a path template into an OpenAPI path, returning the
+// field paths of its variables, e.g. /user/{user_id}/companies
+func parseTemplate(template string) (string, []string) {
+	var fields []string
+	segments := strings.Split(template, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") {
+			continue
+		}
+		end := strings.Index(segment, "}")
+		if end < 0 {
+			continue
+		}
+		variable := segment[1:end]
+		if eq := strings.Index(variable, "="); eq >= 0 {
+			variable = variable[:eq]
+		}
+		fields = append(fields, variable)
+		segments[i] = "{" + variable + "}" + segment[end+1:]
+	}
+	return strings.Join(segments, "/"), fields
+}
+
+// lookupField resolves a dotted field path within a message
+func (g *generator) lookupField(msg *descriptor.DescriptorProto, fieldPath string) (*descriptor.FieldDescriptorProto, error) {
+	names := strings.Split(fieldPath, ".")
+	for i, name := range names {
+		var found *descriptor.FieldDescriptorProto
+		for _, field := range msg.GetField() {
+			if field.GetName() == name || field.GetJsonName() == name {
+				found = field
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("no field %q in %s", name, msg.GetName())
+		}
+		if i == len(names)-1 {
+			return found, nil
+		}
+		next, ok := g.messages[found.GetTypeName()]
+		if !ok {
+			return nil, fmt.Errorf("field %q is not a message", name)
+		}
+		msg = next
+	}
+	return nil, fmt.Errorf("empty field path")
+}
+
+// queryParameters lists the fields not bound elsewhere as query parameters,
+// flattening nested messages into dotted names
+func (g *generator) queryParameters(msg *descriptor.DescriptorProto, prefix string, bound map[string]bool, depth int) []*Parameter {
+	var params []*Parameter
+	for _, field := range msg.GetField() {
+		name := prefix + field.GetName()
+		if bound[name] {
+			continue
+		}
+
+		if nested, ok := g.messages[field.GetTypeName()]; ok {
+			if nested.GetOptions().GetMapEntry() || field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+				continue
+			}
+			if depth < maxQueryDepth {
+				params = append(params, g.queryParameters(nested, name+".", bound, depth+1)...)
+			}
+			continue
+		}
+
+		params = append(params, &Parameter{
+			Name:   name,
+			In:     "query",
+			Schema: g.fieldSchema(field),
+		})
+	}
+	return params
+}
+
+// fieldSchema describes the JSON representation of a field
+func (g *generator) fieldSchema(field *descriptor.FieldDescriptorProto) *Schema {
+	if nested, ok := g.messages[field.GetTypeName()]; ok && nested.GetOptions().GetMapEntry() {
+		return &Schema{
+			Type:                 "object",
+			AdditionalProperties: g.fieldSchema(nested.GetField()[1]),
+		}
+	}
+
+	schema := g.singularSchema(field)
+	if field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		return &Schema{Type: "array", Items: schema}
+	}
+	return schema
+}
+
+func (g *generator) singularSchema(field *descriptor.FieldDescriptorProto) *Schema {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		return &Schema{Type: "number", Format: "double"}
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return &Schema{Type: "number", Format: "float"}
+	case descriptor.FieldDescriptorProto_TYPE_INT32, descriptor.FieldDescriptorProto_TYPE_SINT32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case descriptor.FieldDescriptorProto_TYPE_UINT32, descriptor.FieldDescriptorProto_TYPE_FIXED32:
+		return &Schema{Type: "integer", Format: "uint32"}
+	// 64 bit integers are strings in the proto3 JSON mapping
+	case descriptor.FieldDescriptorProto_TYPE_INT64, descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		return &Schema{Type: "string", Format: "int64"}
+	case descriptor.FieldDescriptorProto_TYPE_UINT64, descriptor.FieldDescriptorProto_TYPE_FIXED64:
+		return &Schema{Type: "string", Format: "uint64"}
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return &Schema{Type: "boolean"}
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return &Schema{Type: "string", Format: "byte"}
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		schema := &Schema{Type: "string"}
+		if ed, ok := g.enums[field.GetTypeName()]; ok {
+			for _, value := range ed.GetValue() {
+				schema.Enum = append(schema.Enum, value.GetName())
+			}
+		}
+		return schema
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
+		return g.messageSchema(field.GetTypeName())
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+// messageSchema returns a reference to the schema of a message, adding it to
+// the components the first time. Well known types are inlined with their
+// special JSON representation.
+func (g *generator) messageSchema(typeName string) *Schema {
+	switch typeName {
+	case ".google.protobuf.Timestamp":
+		return &Schema{Type: "string", Format: "date-time"}
+	case ".google.protobuf.Duration", ".google.protobuf.FieldMask":
+		return &Schema{Type: "string"}
+	case ".google.protobuf.Empty":
+		return &Schema{Type: "object"}
+	}
+
+	name := strings.TrimPrefix(typeName, ".")
+	if _, ok := g.doc.Components.Schemas[name]; ok {
+		return ref(name)
+	}
+	md, ok := g.messages[typeName]
+	if !ok {
+		return &Schema{Type: "object"}
+	}
+
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	// registered before its fields so recursive messages terminate
+	g.doc.Components.Schemas[name] = schema
+	for _, field := range md.GetField() {
+		fieldSchema := g.fieldSchema(field)
+		if rules := fieldRules(field); rules != nil {
+			if rules.GetRequired() {
+				schema.Required = append(schema.Required, field.GetJsonName())
+			}
+			applyRules(fieldSchema, rules)
+		}
+		schema.Properties[field.GetJsonName()] = fieldSchema
+	}
+	return ref(name)
+}
+
+// fieldRules returns the (options.rules) of a field, nil when it has none
+func fieldRules(field *descriptor.FieldDescriptorProto) *optionspb.FieldRules {
+	if field.GetOptions() == nil {
+		return nil
+	}
+	ext, err := proto.GetExtension(field.GetOptions(), optionspb.E_Rules)
+	if err != nil {
+		return nil
+	}
+	rules, _ := ext.(*optionspb.FieldRules)
+	return rules
+}
+
+// applyRules documents the validation rules of a string field, or of the
+// elements of a repeated one. Rules of other types have no JSON schema
+// equivalent, enum values are listed already.
+func applyRules(schema *Schema, rules *optionspb.FieldRules) {
+	if schema.Items != nil {
+		schema = schema.Items
+	}
+	if schema.Type != "string" || schema.Format != "" && schema.Format != "email" {
+		return
+	}
+	schema.MinLength = rules.GetMinLen()
+	schema.MaxLength = rules.GetMaxLen()
+	schema.Pattern = rules.GetPattern()
+	if rules.GetEmail() {
+		schema.Format = "email"
+	}
+	if len(rules.GetIn()) > 0 {
+		schema.Enum = rules.GetIn()
+	}
+}
+
+func ref(schema string) *Schema {
+	return &Schema{Ref: "#/components/schemas/" + schema}
+}
+
+func jsonContent(schema *Schema) map[string]*MediaType {
+	return map[string]*MediaType{
+		"application/json": {Schema: schema},
+	}
+}
@@ -0,0 +1,82 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+)
+
+// Defaults applied when the matching Config field is zero
+const (
+	DefaultPageSize    = 25
+	DefaultMaxPageSize = 100
+)
+
+// Pagination error reasons
+const (
+	ReasonInvalidPageSize  = "PAGE_SIZE_INVALID"
+	ReasonInvalidPageToken = "PAGE_TOKEN_INVALID"
+)
+
+// Pagination errors
+var (
+	ErrInvalidPageSize  = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonInvalidPageSize, "invalid page size")
+	ErrInvalidPageToken = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonInvalidPageToken, "invalid page token").
+				WithFields(apierror.FieldViolation{Field: "pagination.page_token", Description: "is not a token returned by this listing"})
+)
+
+// Config bounds the size of the pages listings return
+type Config struct {
+	// DefaultPageSize applies when the client doesn't ask for a size
+	DefaultPageSize int
+	// MaxPageSize caps the size clients ask for
+	MaxPageSize int
+}
+
+// Size returns the number of results to return for the page_size of a
+// Pagination, capped at the maximum page size
+func (c Config) Size(requested int32) (int, error) {
+	if requested < 0 {
+		return 0, ErrInvalidPageSize.WithFields(apierror.FieldViolation{Field: "pagination.page_size", Description: "must not be negative"})
+	}
+	max := c.MaxPageSize
+	if max <= 0 {
+		max = DefaultMaxPageSize
+	}
+	size := int(requested)
+	if size == 0 {
+		size = c.DefaultPageSize
+		if size <= 0 {
+			size = DefaultPageSize
+		}
+	}
+	if size > max {
+		size = max
+	}
+	return size, nil
+}
+
+// Token encodes cursor, the keyset of the last result of a page, into an
+// opaque page token
+func Token(cursor interface{}) string {
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode decodes a page token returned by Token into cursor, failing with
+// ErrInvalidPageToken when it wasn't issued by this server
+func Decode(token string, cursor interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ErrInvalidPageToken
+	}
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return ErrInvalidPageToken
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package protodesc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+
+	annotations "github.com/nathanows/elegant-monolith/_protos/google/api"
+)
+
+var (
+	mtx   sync.Mutex
+	files = map[string]*descriptor.FileDescriptorProto{}
+)
+
+// File returns the descriptor of a proto file compiled into the binary, e.g.
+// "companyusers/companyusers.proto", as registered by its generated package
+func File(name string) (*descriptor.FileDescriptorProto, error) {
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	if fd, ok := files[name]; ok {
+		return fd, nil
+	}
+
+	gz := proto.FileDescriptor(name)
+	if gz == nil {
+		return nil, fmt.Errorf("protodesc: proto file %q is not registered", name)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	fd := &descriptor.FileDescriptorProto{}
+	if err := proto.Unmarshal(b, fd); err != nil {
+		return nil, err
+	}
+
+	files[name] = fd
+	return fd, nil
+}
+
+// Service returns the descriptor of the fully qualified service, e.g.
+// "companyusers.CompanySvc", declared in the given file
+func Service(file, service string) (*descriptor.ServiceDescriptorProto, error) {
+	fd, err := File(file)
+	if err != nil {
+		return nil, err
+	}
+	for _, sd := range fd.GetService() {
+		if fd.GetPackage()+"."+sd.GetName() == service {
+			return sd, nil
+		}
+	}
+	return nil, fmt.Errorf("protodesc: service %q not found in %q", service, file)
+}
+
+// Message returns the descriptor of a message declared at the top level of
+// the given file, by its fully qualified name as it appears in descriptors,
+// with or without the leading dot
+func Message(file, message string) (*descriptor.DescriptorProto, error) {
+	fd, err := File(file)
+	if err != nil {
+		return nil, err
+	}
+	message = strings.TrimPrefix(message, ".")
+	for _, md := range fd.GetMessageType() {
+		if fd.GetPackage()+"."+md.GetName() == message {
+			return md, nil
+		}
+	}
+	return nil, fmt.Errorf("protodesc: message %q not found in %q", message, file)
+}
+
+// HTTPRule returns the google.api.http annotation of a method, nil if it has
+// none
+func HTTPRule(method *descriptor.MethodDescriptorProto) *annotations.HttpRule {
+	if method.GetOptions() == nil {
+		return nil
+	}
+	ext, err := proto.GetExtension(method.GetOptions(), annotations.E_Http)
+	if err != nil {
+		return nil
+	}
+	rule, _ := ext.(*annotations.HttpRule)
+	return rule
+}
+
+// HTTPPattern returns the HTTP method and path template of a rule
+func HTTPPattern(rule *annotations.HttpRule) (method string, path string) {
+	switch {
+	case rule.GetGet() != "":
+		return "GET", rule.GetGet()
+	case rule.GetPut() != "":
+		return "PUT", rule.GetPut()
+	case rule.GetPost() != "":
+		return "POST", rule.GetPost()
+	case rule.GetDelete() != "":
+		return "DELETE", rule.GetDelete()
+	case rule.GetPatch() != "":
+		return "PATCH", rule.GetPatch()
+	case rule.GetCustom() != nil:
+		return strings.ToUpper(rule.GetCustom().GetKind()), rule.GetCustom().GetPath()
+	default:
+		return "", ""
+	}
+}
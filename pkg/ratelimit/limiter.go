@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Rule configures a token bucket. Rate is the number of requests per second
+// refilled into the bucket and Burst its capacity. A zero Rate disables
+// limiting.
+type Rule struct {
+	Rate  float64
+	Burst int
+}
+
+func (rule Rule) enabled() bool {
+	return rule.Rate > 0
+}
+
+func (rule Rule) capacity() float64 {
+	if rule.Burst < 1 {
+		return 1
+	}
+	return float64(rule.Burst)
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take refills the bucket for the time elapsed since it was last used and
+// consumes a token if one is available. When none is, it returns how long the
+// caller should wait before one will be.
+func (b *bucket) take(rule Rule, now time.Time) (bool, time.Duration) {
+	capacity := rule.capacity()
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.last).Seconds()*rule.Rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := (1 - b.tokens) / rule.Rate
+	return false, time.Duration(wait * float64(time.Second))
+}
+
+// idle reports whether the bucket has refilled completely, at which point it
+// holds no state worth keeping
+func (b *bucket) idle(rule Rule, now time.Time) bool {
+	return b.tokens+now.Sub(b.last).Seconds()*rule.Rate >= rule.capacity()
+}
+
+// sweepInterval is how often buckets that have refilled are dropped so the
+// number of tracked clients doesn't grow without bound
+const sweepInterval = time.Minute
+
+// keyedLimiter holds a token bucket per client key for a single rule
+type keyedLimiter struct {
+	rule Rule
+	now  func() time.Time
+
+	mtx       sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func newKeyedLimiter(rule Rule) *keyedLimiter {
+	return &keyedLimiter{
+		rule:      rule,
+		now:       time.Now,
+		buckets:   map[string]*bucket{},
+		lastSweep: time.Now(),
+	}
+}
+
+func (l *keyedLimiter) allow(key string) (bool, time.Duration) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastSweep) > sweepInterval {
+		for k, b := range l.buckets {
+			if b.idle(l.rule, now) {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.rule.capacity(), last: now}
+		l.buckets[key] = b
+	}
+	return b.take(l.rule, now)
+}
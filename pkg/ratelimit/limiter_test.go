@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucket(t *testing.T) {
+	rule := Rule{Rate: 2, Burst: 3}
+	start := time.Unix(0, 0)
+	b := &bucket{tokens: rule.capacity(), last: start}
+
+	steps := []struct {
+		name  string
+		after time.Duration
+		ok    bool
+		wait  time.Duration
+	}{
+		{"burst 1", 0, true, 0},
+		{"burst 2", 0, true, 0},
+		{"burst 3", 0, true, 0},
+		{"empty", 0, false, 500 * time.Millisecond},
+		{"partly refilled", 250 * time.Millisecond, false, 250 * time.Millisecond},
+		{"refilled one", 500 * time.Millisecond, true, 0},
+		{"empty again", 500 * time.Millisecond, false, 500 * time.Millisecond},
+		{"refill capped by burst", 10 * time.Second, true, 0},
+		{"burst 2 after refill", 10 * time.Second, true, 0},
+		{"burst 3 after refill", 10 * time.Second, true, 0},
+		{"empty after refill", 10 * time.Second, false, 500 * time.Millisecond},
+	}
+	for _, s := range steps {
+		ok, wait := b.take(rule, start.Add(s.after))
+		if ok != s.ok || wait != s.wait {
+			t.Errorf("%s: take() = %v, %s, want %v, %s", s.name, ok, wait, s.ok, s.wait)
+		}
+	}
+}
+
+func TestBucketIdle(t *testing.T) {
+	rule := Rule{Rate: 1, Burst: 2}
+	start := time.Unix(0, 0)
+	b := &bucket{tokens: rule.capacity(), last: start}
+	b.take(rule, start)
+	if b.idle(rule, start) {
+		t.Error("idle() right after take() = true, want false")
+	}
+	if !b.idle(rule, start.Add(time.Second)) {
+		t.Error("idle() once refilled = false, want true")
+	}
+}
+
+func TestRuleCapacity(t *testing.T) {
+	if c := (Rule{Rate: 1}).capacity(); c != 1 {
+		t.Errorf("capacity() without burst = %v, want 1", c)
+	}
+}
+
+func TestKeyedLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := newKeyedLimiter(Rule{Rate: 1, Burst: 1})
+	l.now = func() time.Time { return now }
+	l.lastSweep = now
+
+	if ok, _ := l.allow("a"); !ok {
+		t.Fatal("first request of a rejected")
+	}
+	if ok, _ := l.allow("a"); ok {
+		t.Error("second request of a allowed")
+	}
+	if ok, _ := l.allow("b"); !ok {
+		t.Error("first request of b rejected, buckets should be per key")
+	}
+
+	now = now.Add(2 * sweepInterval)
+	if ok, _ := l.allow("a"); !ok {
+		t.Error("request of a after refill rejected")
+	}
+	if _, ok := l.buckets["b"]; ok {
+		t.Error("idle bucket of b kept after a sweep")
+	}
+}
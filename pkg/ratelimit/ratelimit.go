@@ -0,0 +1,234 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/kit/endpoint"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+	"github.com/nathanows/elegant-monolith/pkg/auth"
+)
+
+// Rate limiting error reasons
+const (
+	ReasonRateLimited = "RATE_LIMITED"
+	ReasonOverloaded  = "OVERLOADED"
+)
+
+// LimitError is returned when a request is rejected by a Limiter. RetryAfter
+// is a hint for when the client may try again.
+type LimitError struct {
+	RetryAfter time.Duration
+	// Overloaded is set when the request was shed because the server as a
+	// whole is at capacity rather than because the client exceeded its limit
+	Overloaded bool
+}
+
+func (e *LimitError) Error() string {
+	if e.Overloaded {
+		return "server overloaded"
+	}
+	return "rate limit exceeded"
+}
+
+// GRPCStatus maps the error to ResourceExhausted, or Unavailable when
+// overloaded, with RetryInfo details
+func (e *LimitError) GRPCStatus() *status.Status {
+	err := apierror.New(codespb.Code_RESOURCE_EXHAUSTED, ReasonRateLimited, e.Error())
+	if e.Overloaded {
+		err = apierror.New(codespb.Code_UNAVAILABLE, ReasonOverloaded, e.Error())
+	}
+	st := err.GRPCStatus()
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(e.RetryAfter)}); err == nil {
+		return detailed
+	}
+	return st
+}
+
+// Config configures a Limiter
+type Config struct {
+	// Default applies to endpoints without an entry in Endpoints
+	Default Rule
+	// Endpoints is keyed by service, then method name
+	Endpoints map[string]map[string]Rule
+	// PerIP limits the requests of each client IP across all endpoints,
+	// before authentication, so that requests with missing or invalid API
+	// keys are limited too
+	PerIP Rule
+	// MaxInFlight caps concurrent requests across all endpoints, zero leaves
+	// concurrency unlimited
+	MaxInFlight int
+	// TrustForwardedFor identifies clients by the X-Forwarded-For header
+	// rather than the connection's address. Only enable it behind a proxy
+	// that sets the header.
+	TrustForwardedFor bool
+}
+
+// Limiter enforces per-client token bucket rate limits on endpoints, and an
+// optional global cap on requests in flight
+type Limiter struct {
+	defaultRule       Rule
+	rules             map[string]map[string]Rule
+	perIP             *keyedLimiter
+	inFlight          chan struct{}
+	trustForwardedFor bool
+}
+
+// NewLimiter returns an initialized Limiter
+func NewLimiter(config Config) *Limiter {
+	l := &Limiter{
+		defaultRule:       config.Default,
+		rules:             map[string]map[string]Rule{},
+		trustForwardedFor: config.TrustForwardedFor,
+	}
+	// config keys are case insensitive
+	for service, methods := range config.Endpoints {
+		l.rules[strings.ToLower(service)] = map[string]Rule{}
+		for method, rule := range methods {
+			l.rules[strings.ToLower(service)][strings.ToLower(method)] = rule
+		}
+	}
+	if config.PerIP.enabled() {
+		l.perIP = newKeyedLimiter(config.PerIP)
+	}
+	if config.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, config.MaxInFlight)
+	}
+	return l
+}
+
+func (l *Limiter) rule(service, method string) Rule {
+	if rule, ok := l.rules[strings.ToLower(service)][strings.ToLower(method)]; ok {
+		return rule
+	}
+	return l.defaultRule
+}
+
+// Middleware returns an endpoint middleware rate limiting the given service
+// method per client. Clients are identified by their authenticated principal
+// when there is one, otherwise by IP, so it must be placed inside the auth
+// middleware.
+func (l *Limiter) Middleware(service, method string) endpoint.Middleware {
+	rule := l.rule(service, method)
+	if !rule.enabled() {
+		return passthrough
+	}
+
+	limiter := newKeyedLimiter(rule)
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			if ok, retryAfter := limiter.allow(l.clientKey(ctx)); !ok {
+				return nil, &LimitError{RetryAfter: retryAfter}
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// IPMiddleware returns an endpoint middleware rate limiting the requests of
+// each client IP, with a limit shared by every endpoint it is applied to. It
+// must be placed outside the auth middleware, so that unauthenticated
+// requests are limited before costing a key lookup.
+func (l *Limiter) IPMiddleware() endpoint.Middleware {
+	if l.perIP == nil {
+		return passthrough
+	}
+
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			if ok, retryAfter := l.perIP.allow(l.clientIP(ctx)); !ok {
+				return nil, &LimitError{RetryAfter: retryAfter}
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// ConcurrencyMiddleware returns an endpoint middleware shedding requests once
+// the Limiter's in-flight limit, shared by every endpoint it is applied to,
+// is reached
+func (l *Limiter) ConcurrencyMiddleware() endpoint.Middleware {
+	if l.inFlight == nil {
+		return passthrough
+	}
+
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			select {
+			case l.inFlight <- struct{}{}:
+				defer func() { <-l.inFlight }()
+			default:
+				return nil, &LimitError{RetryAfter: time.Second, Overloaded: true}
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+func passthrough(next endpoint.Endpoint) endpoint.Endpoint {
+	return next
+}
+
+type contextKey int
+
+const clientAddrContextKey contextKey = iota
+
+type clientAddr struct {
+	remote       string
+	forwardedFor string
+}
+
+// GRPCToContext records the address of the client making the request in the
+// context. Meant to be used as a go-kit grpctransport.ServerBefore option.
+func GRPCToContext() func(context.Context, metadata.MD) context.Context {
+	return func(ctx context.Context, md metadata.MD) context.Context {
+		addr := clientAddr{}
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			addr.remote = hostOnly(p.Addr.String())
+		}
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			addr.forwardedFor = firstForwardedFor(values[0])
+		}
+		return context.WithValue(ctx, clientAddrContextKey, addr)
+	}
+}
+
+func hostOnly(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+func firstForwardedFor(header string) string {
+	return strings.TrimSpace(strings.Split(header, ",")[0])
+}
+
+func (l *Limiter) clientKey(ctx context.Context) string {
+	if principal, ok := auth.FromContext(ctx); ok {
+		return principal.Kind + ":" + strconv.FormatInt(principal.ID, 10)
+	}
+	return l.clientIP(ctx)
+}
+
+func (l *Limiter) clientIP(ctx context.Context) string {
+	addr, _ := ctx.Value(clientAddrContextKey).(clientAddr)
+	if l.trustForwardedFor && addr.forwardedFor != "" {
+		return "ip:" + addr.forwardedFor
+	}
+	if addr.remote != "" {
+		return "ip:" + addr.remote
+	}
+	return "anonymous"
+}
@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/nathanows/elegant-monolith/pkg/auth"
+)
+
+func ok(context.Context, interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+// fromIP returns the context of a request from ip, forwarded for forwardedFor
+// when set
+func fromIP(ip, forwardedFor string) context.Context {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 1234}})
+	md := metadata.MD{}
+	if forwardedFor != "" {
+		md = metadata.Pairs("x-forwarded-for", forwardedFor)
+	}
+	return GRPCToContext()(ctx, md)
+}
+
+func asPrincipal(ctx context.Context, id int64) context.Context {
+	return auth.NewContext(ctx, &auth.Principal{Kind: "apikey", ID: id})
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		first   context.Context
+		second  context.Context
+		limited bool
+	}{
+		{
+			name:    "same IP",
+			config:  Config{Default: Rule{Rate: 1, Burst: 1}},
+			first:   fromIP("10.0.0.1", ""),
+			second:  fromIP("10.0.0.1", ""),
+			limited: true,
+		},
+		{
+			name:   "other IP",
+			config: Config{Default: Rule{Rate: 1, Burst: 1}},
+			first:  fromIP("10.0.0.1", ""),
+			second: fromIP("10.0.0.2", ""),
+		},
+		{
+			name:   "other principal on the same IP",
+			config: Config{Default: Rule{Rate: 1, Burst: 1}},
+			first:  asPrincipal(fromIP("10.0.0.1", ""), 1),
+			second: asPrincipal(fromIP("10.0.0.1", ""), 2),
+		},
+		{
+			name:    "same principal on other IPs",
+			config:  Config{Default: Rule{Rate: 1, Burst: 1}},
+			first:   asPrincipal(fromIP("10.0.0.1", ""), 1),
+			second:  asPrincipal(fromIP("10.0.0.2", ""), 1),
+			limited: true,
+		},
+		{
+			name:   "untrusted X-Forwarded-For",
+			config: Config{Default: Rule{Rate: 1, Burst: 1}},
+			first:  fromIP("10.0.0.1", "1.1.1.1"),
+			second: fromIP("10.0.0.2", "1.1.1.1"),
+		},
+		{
+			name:    "trusted X-Forwarded-For",
+			config:  Config{Default: Rule{Rate: 1, Burst: 1}, TrustForwardedFor: true},
+			first:   fromIP("10.0.0.1", "1.1.1.1, 10.0.0.1"),
+			second:  fromIP("10.0.0.2", "1.1.1.1"),
+			limited: true,
+		},
+		{
+			name: "endpoint rule",
+			config: Config{
+				Default:   Rule{Rate: 1, Burst: 1},
+				Endpoints: map[string]map[string]Rule{"companysvc": {"SAVE": {Rate: 1, Burst: 2}}},
+			},
+			first:  fromIP("10.0.0.1", ""),
+			second: fromIP("10.0.0.1", ""),
+		},
+		{
+			name:   "disabled",
+			config: Config{},
+			first:  fromIP("10.0.0.1", ""),
+			second: fromIP("10.0.0.1", ""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewLimiter(tt.config).Middleware("CompanySvc", "Save")(ok)
+			if _, err := e(tt.first, nil); err != nil {
+				t.Fatalf("first request failed: %v", err)
+			}
+			_, err := e(tt.second, nil)
+			if limited := err != nil; limited != tt.limited {
+				t.Fatalf("second request error = %v, want limited %v", err, tt.limited)
+			}
+			if err == nil {
+				return
+			}
+			limitErr, isLimit := err.(*LimitError)
+			if !isLimit || limitErr.Overloaded || limitErr.RetryAfter <= 0 || limitErr.RetryAfter > time.Second {
+				t.Errorf("second request error = %#v, want a LimitError retrying within 1s", err)
+			}
+			if code := status.Code(err); code != codes.ResourceExhausted {
+				t.Errorf("second request code = %s, want %s", code, codes.ResourceExhausted)
+			}
+		})
+	}
+}
+
+func TestConcurrencyMiddleware(t *testing.T) {
+	l := NewLimiter(Config{MaxInFlight: 1})
+	var (
+		entered = make(chan struct{})
+		release = make(chan struct{})
+	)
+	blocking := l.ConcurrencyMiddleware()(func(context.Context, interface{}) (interface{}, error) {
+		close(entered)
+		<-release
+		return nil, nil
+	})
+	done := make(chan error)
+	go func() {
+		_, err := blocking(context.Background(), nil)
+		done <- err
+	}()
+	<-entered
+
+	_, err := l.ConcurrencyMiddleware()(ok)(context.Background(), nil)
+	if limitErr, isLimit := err.(*LimitError); !isLimit || !limitErr.Overloaded {
+		t.Errorf("request past the in-flight limit error = %v, want an overloaded LimitError", err)
+	}
+	if code := status.Code(err); code != codes.Unavailable {
+		t.Errorf("request past the in-flight limit code = %s, want %s", code, codes.Unavailable)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("request in flight failed: %v", err)
+	}
+	if _, err := l.ConcurrencyMiddleware()(ok)(context.Background(), nil); err != nil {
+		t.Errorf("request once the limit freed up failed: %v", err)
+	}
+}
+
+func TestIPMiddleware(t *testing.T) {
+	l := NewLimiter(Config{PerIP: Rule{Rate: 1, Burst: 1}})
+	save := l.IPMiddleware()(ok)
+	find := l.IPMiddleware()(ok)
+	if _, err := save(asPrincipal(fromIP("10.0.0.1", ""), 1), nil); err != nil {
+		t.Fatalf("first request failed: %v", err)
+	}
+	if _, err := find(asPrincipal(fromIP("10.0.0.1", ""), 2), nil); err == nil {
+		t.Error("second request from the IP allowed, the limit is per IP across endpoints and principals")
+	}
+	if _, err := find(fromIP("10.0.0.2", ""), nil); err != nil {
+		t.Errorf("request from another IP failed: %v", err)
+	}
+
+	disabled := NewLimiter(Config{}).IPMiddleware()(ok)
+	for i := 0; i < 3; i++ {
+		if _, err := disabled(fromIP("10.0.0.1", ""), nil); err != nil {
+			t.Fatalf("request %d without a per IP rule failed: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package recovery
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/go-kit/kit/log"
+	"github.com/go-kit/kit/log/level"
+	"google.golang.org/grpc"
+
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+	"github.com/nathanows/elegant-monolith/pkg/errreport"
+	"github.com/nathanows/elegant-monolith/pkg/requestid"
+)
+
+// panics counts recovered panics by transport, published with the other
+// expvar metrics
+var panics = expvar.NewMap("panics_recovered")
+
+// HTTPMiddleware returns a middleware that recovers panics in the wrapped
+// handler, logging and reporting them with their stack and responding 500
+// rather than letting them take down the process. A panic after the response
+// started can't turn it into a 500, the connection is aborted instead so the
+// client doesn't take the truncated response for a complete one.
+func HTTPMiddleware(logger log.Logger, reporter errreport.Reporter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := &responseWriter{ResponseWriter: w}
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if rec == http.ErrAbortHandler {
+					// deliberate aborts are left to net/http
+					panic(rec)
+				}
+				report(r.Context(), logger, reporter, "HTTP", r.Method+" "+r.URL.Path, rec)
+				if rw.wroteHeader {
+					panic(http.ErrAbortHandler)
+				}
+				apierror.ErrInternal.WriteHTTP(w, apierror.ErrInternal.HTTPStatus())
+			}()
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// responseWriter records whether the response started. It passes flushes
+// through for streamed gRPC-Web responses.
+type responseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) Flush() {
+	w.wroteHeader = true
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *responseWriter) CloseNotify() <-chan bool {
+	if notifier, ok := w.ResponseWriter.(http.CloseNotifier); ok {
+		return notifier.CloseNotify()
+	}
+	return make(chan bool)
+}
+
+// UnaryServerInterceptor is the gRPC unary equivalent of HTTPMiddleware,
+// failing the call with apierror.ErrInternal
+func UnaryServerInterceptor(logger log.Logger, reporter errreport.Reporter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				report(ctx, logger, reporter, "gRPC", info.FullMethod, rec)
+				err = apierror.ErrInternal
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the gRPC streaming equivalent of HTTPMiddleware
+func StreamServerInterceptor(logger log.Logger, reporter errreport.Reporter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				report(ss.Context(), logger, reporter, "gRPC", info.FullMethod, rec)
+				err = apierror.ErrInternal
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func report(ctx context.Context, logger log.Logger, reporter errreport.Reporter, transport, method string, rec interface{}) {
+	panics.Add(transport, 1)
+	reporter.Report(ctx, errreport.Event{
+		Err:   fmt.Errorf("panic: %v", rec),
+		Stack: errreport.Callers(1),
+		Tags:  map[string]string{"transport": transport, "method": method, "panic": "true"},
+	})
+	level.Error(logger).Log(
+		"transport", transport,
+		"method", method,
+		"request_id", requestid.FromContext(ctx),
+		"panic", fmt.Sprint(rec),
+		"stack", string(debug.Stack()),
+	)
+}
@@ -0,0 +1,79 @@
+package recovery
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+
+	"github.com/nathanows/elegant-monolith/pkg/errreport"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		status  int
+		aborted bool
+	}{
+		{
+			name:    "no panic",
+			handler: func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) },
+			status:  http.StatusNoContent,
+		},
+		{
+			name:    "panic before the response",
+			handler: func(http.ResponseWriter, *http.Request) { panic("failure") },
+			status:  http.StatusInternalServerError,
+		},
+		{
+			name: "panic after the header",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				panic("failure")
+			},
+			status:  http.StatusOK,
+			aborted: true,
+		},
+		{
+			name: "panic after the body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("{"))
+				panic("failure")
+			},
+			status:  http.StatusOK,
+			aborted: true,
+		},
+		{
+			name:    "abort",
+			handler: func(http.ResponseWriter, *http.Request) { panic(http.ErrAbortHandler) },
+			status:  http.StatusOK,
+			aborted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h := HTTPMiddleware(log.NewNopLogger(), errreport.NopReporter())(tt.handler)
+			aborted := func() (aborted bool) {
+				defer func() {
+					if rec := recover(); rec != nil {
+						if rec != http.ErrAbortHandler {
+							t.Fatalf("panic %v escaped, want %v", rec, http.ErrAbortHandler)
+						}
+						aborted = true
+					}
+				}()
+				h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+				return false
+			}()
+			if aborted != tt.aborted {
+				t.Errorf("aborted = %v, want %v", aborted, tt.aborted)
+			}
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package redact
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-kit/kit/log"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+
+	optionspb "github.com/nathanows/elegant-monolith/_protos/options"
+)
+
+// Mask replaces sensitive string values
+const Mask = "[REDACTED]"
+
+// disabled is set to let sensitive values through, for local development
+var disabled int32
+
+// SetEnabled turns redaction on, the default, or off
+func SetEnabled(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&disabled, 0)
+	} else {
+		atomic.StoreInt32(&disabled, 1)
+	}
+}
+
+// Enabled reports whether sensitive values are masked
+func Enabled() bool {
+	return atomic.LoadInt32(&disabled) == 0
+}
+
+// fields describes which fields of a message struct need attention
+type fields struct {
+	sensitive []int // struct field indices to mask
+	nested    []int // struct field indices holding messages to walk
+	names     map[string]bool
+}
+
+var (
+	mtx      sync.RWMutex
+	registry = map[reflect.Type]*fields{}
+)
+
+// Register marks fields of a message as sensitive by proto or JSON name, for
+// messages that can't carry the (options.sensitive) field option
+func Register(msg proto.Message, names ...string) {
+	t := reflect.TypeOf(msg).Elem()
+	f := lookup(t)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	props := proto.GetProperties(t)
+	for i, prop := range props.Prop {
+		for _, name := range names {
+			if (prop.OrigName == name || prop.JSONName == name) && !f.names[prop.OrigName] {
+				f.sensitive = append(f.sensitive, i)
+				f.names[prop.OrigName] = true
+			}
+		}
+	}
+}
+
+// IsSensitive reports whether the named field of msg, by proto or JSON name,
+// holds sensitive data
+func IsSensitive(msg proto.Message, name string) bool {
+	t := reflect.TypeOf(msg)
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return false
+	}
+	f := lookup(t.Elem())
+
+	mtx.RLock()
+	defer mtx.RUnlock()
+	for _, prop := range proto.GetProperties(t.Elem()).Prop {
+		if prop.OrigName == name || prop.JSONName == name {
+			return f.names[prop.OrigName]
+		}
+	}
+	return false
+}
+
+// Message returns a copy of msg with its sensitive fields masked, nested
+// messages included. msg itself is returned when redaction is disabled.
+func Message(msg proto.Message) proto.Message {
+	if !Enabled() || msg == nil || reflect.ValueOf(msg).IsNil() {
+		return msg
+	}
+	masked := proto.Clone(msg)
+	mask(reflect.ValueOf(masked))
+	return masked
+}
+
+// Value returns value, or Mask if the named field of msg is sensitive. Meant
+// for messages that echo a field's value, e.g. validation errors.
+func Value(msg proto.Message, name string, value interface{}) interface{} {
+	if Enabled() && IsSensitive(msg, name) {
+		return Mask
+	}
+	return value
+}
+
+// Logger returns a logger masking the sensitive fields of the proto messages
+// it is handed
+func Logger(next log.Logger) log.Logger {
+	return log.LoggerFunc(func(keyvals ...interface{}) error {
+		copied := false
+		for i := 1; i < len(keyvals); i += 2 {
+			msg, ok := keyvals[i].(proto.Message)
+			if !ok {
+				continue
+			}
+			// the caller's keyvals may be shared with other loggers
+			if !copied {
+				keyvals = append([]interface{}(nil), keyvals...)
+				copied = true
+			}
+			keyvals[i] = Message(msg)
+		}
+		return next.Log(keyvals...)
+	})
+}
+
+func mask(v reflect.Value) {
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	s := v.Elem()
+	f := lookup(s.Type())
+
+	mtx.RLock()
+	sensitive, nested := f.sensitive, f.nested
+	mtx.RUnlock()
+
+	for _, i := range sensitive {
+		maskValue(s.Field(i))
+	}
+	for _, i := range nested {
+		field := s.Field(i)
+		switch field.Kind() {
+		case reflect.Ptr:
+			mask(field)
+		case reflect.Slice:
+			for j := 0; j < field.Len(); j++ {
+				mask(field.Index(j))
+			}
+		}
+	}
+}
+
+func maskValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		if v.Len() > 0 {
+			v.SetString(Mask)
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String {
+			for i := 0; i < v.Len(); i++ {
+				maskValue(v.Index(i))
+			}
+			return
+		}
+		v.Set(reflect.Zero(v.Type()))
+	default:
+		v.Set(reflect.Zero(v.Type()))
+	}
+}
+
+// lookup returns the fields of a message struct type, reading the
+// (options.sensitive) field options from its descriptor the first time
+func lookup(t reflect.Type) *fields {
+	mtx.RLock()
+	f, ok := registry[t]
+	mtx.RUnlock()
+	if ok {
+		return f
+	}
+
+	f = &fields{names: map[string]bool{}}
+	var md *descriptor.DescriptorProto
+	if msg, ok := reflect.New(t).Interface().(descriptor.Message); ok {
+		_, md = descriptor.ForMessage(msg)
+	}
+
+	for i, prop := range proto.GetProperties(t).Prop {
+		field := t.Field(i)
+		if strings.HasPrefix(field.Name, "XXX_") {
+			continue
+		}
+		if isSensitive(md, prop.OrigName) {
+			f.sensitive = append(f.sensitive, i)
+			f.names[prop.OrigName] = true
+			continue
+		}
+
+		ft := field.Type
+		if ft.Kind() == reflect.Slice {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Ptr && ft.Elem().Kind() == reflect.Struct {
+			f.nested = append(f.nested, i)
+		}
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	if existing, ok := registry[t]; ok {
+		return existing
+	}
+	registry[t] = f
+	return f
+}
+
+func isSensitive(md *descriptor.DescriptorProto, name string) bool {
+	if md == nil {
+		return false
+	}
+	for _, field := range md.GetField() {
+		if field.GetName() != name || field.GetOptions() == nil {
+			continue
+		}
+		ext, err := proto.GetExtension(field.GetOptions(), optionspb.E_Sensitive)
+		if err != nil {
+			return false
+		}
+		sensitive, _ := ext.(*bool)
+		return sensitive != nil && *sensitive
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Header is the HTTP header request IDs are read from and echoed back in
+const Header = "X-Request-Id"
+
+// Metadata is the gRPC metadata equivalent of Header
+const Metadata = "x-request-id"
+
+// maxLength bounds client supplied IDs so they can't bloat every log line
+const maxLength = 128
+
+type contextKey int
+
+const requestIDContextKey contextKey = iota
+
+// NewContext returns a copy of ctx carrying the given request ID
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, id)
+}
+
+// FromContext returns the request ID, or an empty string if there isn't one
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey).(string)
+	return id
+}
+
+// HTTPMiddleware assigns every request an ID, reusing the caller's if one is
+// passed in the X-Request-Id header, and echoes it in the response
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := valid(r.Header.Get(Header))
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), id)))
+	})
+}
+
+// UnaryServerInterceptor is the gRPC unary equivalent of HTTPMiddleware
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(fromIncoming(ctx), req)
+	}
+}
+
+// StreamServerInterceptor is the gRPC streaming equivalent of HTTPMiddleware
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, serverStream{ss, fromIncoming(ss.Context())})
+	}
+}
+
+func fromIncoming(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(Metadata); len(ids) > 0 {
+			id = ids[0]
+		}
+	}
+	id = valid(id)
+	grpc.SetHeader(ctx, metadata.Pairs(Metadata, id))
+	return NewContext(ctx, id)
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// valid returns id if it is usable, otherwise a newly generated one
+func valid(id string) string {
+	if id != "" && len(id) <= maxLength {
+		return id
+	}
+	return generate()
+}
+
+func generate() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,252 @@
+package transcode
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/gorilla/mux"
+
+	"github.com/nathanows/elegant-monolith/pkg/httpcodec"
+)
+
+// BindError is returned when a path variable or query parameter can't be
+// bound to the request message
+type BindError struct {
+	Param string
+	Err   error
+}
+
+func (e *BindError) Error() string {
+	return fmt.Sprintf("invalid parameter %q: %s", e.Param, e.Err)
+}
+
+var (
+	timestampType = reflect.TypeOf(types.Timestamp{})
+	fieldMaskType = reflect.TypeOf(types.FieldMask{})
+)
+
+// bind fills req, a pointer to the request message, from the request body,
+// path variables and query parameters in that order, later sources winning.
+// Query parameters are only considered when the body doesn't already map the
+// whole message.
+func (rt *route) bind(r *http.Request, req reflect.Value) error {
+	switch rt.body {
+	case "":
+	case "*":
+		if err := httpcodec.DecodeRequest(r, req.Interface().(proto.Message)); err != nil {
+			return err
+		}
+	default:
+		field, _ := messageField(rt.requestType, rt.body)
+		msg := reflect.New(field.Type.Elem())
+		if err := httpcodec.DecodeRequest(r, msg.Interface().(proto.Message)); err != nil {
+			return err
+		}
+		req.Elem().FieldByIndex(field.Index).Set(msg)
+	}
+
+	if rt.body != "*" {
+		for param, values := range r.URL.Query() {
+			if err := setField(req, strings.Split(param, "."), values); err != nil {
+				return &BindError{param, err}
+			}
+		}
+	}
+
+	for name, value := range mux.Vars(r) {
+		path := rt.pathVars[name]
+		if err := setField(req, path, []string{value}); err != nil {
+			return &BindError{strings.Join(path, "."), err}
+		}
+	}
+	return nil
+}
+
+// lookupField finds the field of a generated message struct by its proto or
+// JSON name
+func lookupField(t reflect.Type, name string) (reflect.StructField, *proto.Properties, bool) {
+	props := proto.GetProperties(t)
+	for i, prop := range props.Prop {
+		field := t.Field(i)
+		if strings.HasPrefix(field.Name, "XXX_") || field.Tag.Get("protobuf_oneof") != "" {
+			continue
+		}
+		if prop.OrigName == name || prop.JSONName == name {
+			return field, prop, true
+		}
+	}
+	return reflect.StructField{}, nil, false
+}
+
+// messageField resolves a top level singular message field, the only kind a
+// body can be mapped to
+func messageField(t reflect.Type, name string) (reflect.StructField, error) {
+	field, _, ok := lookupField(t, name)
+	if !ok {
+		return field, fmt.Errorf("no field %q in %s", name, t.Name())
+	}
+	if field.Type.Kind() != reflect.Ptr || field.Type.Elem().Kind() != reflect.Struct {
+		return field, fmt.Errorf("field %q is not a message", name)
+	}
+	return field, nil
+}
+
+// fieldKind returns the kind of the field at path
+func fieldKind(t reflect.Type, path []string) (reflect.Kind, error) {
+	for i, name := range path {
+		field, _, ok := lookupField(t, name)
+		if !ok {
+			return reflect.Invalid, fmt.Errorf("no field %q in %s", name, t.Name())
+		}
+		if i == len(path)-1 {
+			return field.Type.Kind(), nil
+		}
+		if field.Type.Kind() != reflect.Ptr || field.Type.Elem().Kind() != reflect.Struct {
+			return reflect.Invalid, fmt.Errorf("field %q is not a message", name)
+		}
+		t = field.Type.Elem()
+	}
+	return reflect.Invalid, fmt.Errorf("empty field path")
+}
+
+// setField sets the field at path in msg, a pointer to a message struct,
+// allocating intermediate messages as it goes
+func setField(msg reflect.Value, path []string, values []string) error {
+	v := msg.Elem()
+	for i, name := range path {
+		field, prop, ok := lookupField(v.Type(), name)
+		if !ok {
+			return fmt.Errorf("unknown field %q", name)
+		}
+		f := v.FieldByIndex(field.Index)
+
+		if i == len(path)-1 {
+			return setValue(f, prop, values)
+		}
+		if f.Kind() != reflect.Ptr || f.Type().Elem().Kind() != reflect.Struct || isWellKnown(f.Type().Elem()) {
+			return fmt.Errorf("field %q is not a message", name)
+		}
+		if f.IsNil() {
+			f.Set(reflect.New(f.Type().Elem()))
+		}
+		v = f.Elem()
+	}
+	return nil
+}
+
+func setValue(f reflect.Value, prop *proto.Properties, values []string) error {
+	if f.Kind() == reflect.Slice && f.Type().Elem().Kind() != reflect.Uint8 {
+		for _, value := range values {
+			elem, err := parseValue(f.Type().Elem(), prop, value)
+			if err != nil {
+				return err
+			}
+			f.Set(reflect.Append(f, elem))
+		}
+		return nil
+	}
+
+	if len(values) != 1 {
+		return fmt.Errorf("expected a single value, got %d", len(values))
+	}
+	v, err := parseValue(f.Type(), prop, values[0])
+	if err != nil {
+		return err
+	}
+	f.Set(v)
+	return nil
+}
+
+func isWellKnown(t reflect.Type) bool {
+	return t == timestampType || t == fieldMaskType
+}
+
+// parseValue parses a single value of type t, accepting the same
+// representations as the proto3 JSON mapping
+func parseValue(t reflect.Type, prop *proto.Properties, value string) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+
+	switch {
+	case t.Kind() == reflect.Ptr && t.Elem() == timestampType:
+		parsed, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return v, err
+		}
+		ts, err := types.TimestampProto(parsed)
+		if err != nil {
+			return v, err
+		}
+		return reflect.ValueOf(ts), nil
+	case t.Kind() == reflect.Ptr && t.Elem() == fieldMaskType:
+		mask := &types.FieldMask{}
+		for _, path := range strings.Split(value, ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				mask.Paths = append(mask.Paths, path)
+			}
+		}
+		return reflect.ValueOf(mask), nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return v, err
+		}
+		v.SetBool(b)
+	case reflect.Int32, reflect.Int64:
+		if prop != nil && prop.Enum != "" {
+			if n, ok := proto.EnumValueMap(prop.Enum)[value]; ok {
+				v.SetInt(int64(n))
+				return v, nil
+			}
+		}
+		n, err := strconv.ParseInt(value, 10, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetInt(n)
+	case reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, t.Bits())
+		if err != nil {
+			return v, err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		b, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			if b, err = base64.URLEncoding.DecodeString(value); err != nil {
+				return v, err
+			}
+		}
+		v.SetBytes(b)
+	default:
+		return v, fmt.Errorf("unsupported field type %s", t)
+	}
+	return v, nil
+}
+
+// selectField returns the named field of resp, a pointer to a message struct,
+// for rules with a response_body
+func selectField(resp reflect.Value, name string) interface{} {
+	field, _, ok := lookupField(resp.Elem().Type(), name)
+	if !ok {
+		return resp.Interface()
+	}
+	return resp.Elem().FieldByIndex(field.Index).Interface()
+}
@@ -0,0 +1,326 @@
+package transcode
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	"github.com/gorilla/mux"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	annotations "github.com/nathanows/elegant-monolith/_protos/google/api"
+	"github.com/nathanows/elegant-monolith/pkg/accesslog"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+	"github.com/nathanows/elegant-monolith/pkg/etag"
+	"github.com/nathanows/elegant-monolith/pkg/httpcodec"
+	"github.com/nathanows/elegant-monolith/pkg/protodesc"
+)
+
+// Reasons of the errors raised by the transcoder itself
+const (
+	ReasonInvalidRequest       = "INVALID_REQUEST"
+	ReasonUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+)
+
+// Handler serves the REST mapping of gRPC services, as declared by the
+// google.api.http annotations of their methods. Requests are bound to the
+// method's input message and handed to the same server implementation
+// registered with the gRPC server, so both transports share one contract and
+// one middleware chain.
+type Handler struct {
+	router *mux.Router
+}
+
+// NewHandler returns an empty Handler, services are added with Register
+func NewHandler() *Handler {
+	return &Handler{router: mux.NewRouter()}
+}
+
+// Register mounts every annotated unary method of the fully qualified
+// service, e.g. "companyusers.CompanySvc", declared in the given proto file.
+// srv is the service's gRPC server implementation.
+func (h *Handler) Register(file, service string, srv interface{}) error {
+	sd, err := protodesc.Service(file, service)
+	if err != nil {
+		return err
+	}
+
+	for _, md := range sd.GetMethod() {
+		rule := protodesc.HTTPRule(md)
+		if rule == nil {
+			continue
+		}
+		if md.GetClientStreaming() || md.GetServerStreaming() {
+			return fmt.Errorf("transcode: %s.%s is streaming, only unary methods can be mapped to HTTP", service, md.GetName())
+		}
+
+		bindings := append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...)
+		for _, binding := range bindings {
+			rt, err := newRoute(service, srv, md, binding)
+			if err != nil {
+				return err
+			}
+			h.router.Methods(rt.httpMethod).Path(rt.muxPath).Handler(rt)
+		}
+	}
+	return nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.router.ServeHTTP(w, r)
+}
+
+// route is a single HTTP binding of a gRPC method
+type route struct {
+	fullMethod   string
+	httpMethod   string
+	template     string
+	muxPath      string
+	pathVars     map[string][]string // mux variable name to field path
+	body         string
+	responseBody string
+	call         reflect.Value
+	requestType  reflect.Type
+}
+
+func newRoute(service string, srv interface{}, md *descriptor.MethodDescriptorProto, rule *annotations.HttpRule) (*route, error) {
+	fullMethod := "/" + service + "/" + md.GetName()
+
+	call := reflect.ValueOf(srv).MethodByName(md.GetName())
+	if !call.IsValid() {
+		return nil, fmt.Errorf("transcode: %T does not implement %s", srv, fullMethod)
+	}
+	requestType := proto.MessageType(strings.TrimPrefix(md.GetInputType(), "."))
+	if requestType == nil {
+		return nil, fmt.Errorf("transcode: unknown input type %s of %s", md.GetInputType(), fullMethod)
+	}
+
+	httpMethod, template := protodesc.HTTPPattern(rule)
+	if template == "" {
+		return nil, fmt.Errorf("transcode: %s has no HTTP pattern", fullMethod)
+	}
+
+	rt := &route{
+		fullMethod:   fullMethod,
+		httpMethod:   httpMethod,
+		template:     template,
+		pathVars:     map[string][]string{},
+		body:         rule.GetBody(),
+		responseBody: rule.GetResponseBody(),
+		call:         call,
+		requestType:  requestType.Elem(),
+	}
+
+	var err error
+	if rt.muxPath, err = rt.parseTemplate(template); err != nil {
+		return nil, fmt.Errorf("transcode: %s: %s", fullMethod, err)
+	}
+	if rt.body != "" && rt.body != "*" {
+		if _, err := messageField(rt.requestType, rt.body); err != nil {
+			return nil, fmt.Errorf("transcode: %s body: %s", fullMethod, err)
+		}
+	}
+	return rt, nil
+}
+
+// parseTemplate turns a path template such as /company/{id} into a mux path,
+// remembering which request field each variable binds to. Variables bound to
+// integer fields only match digits, so /company/{id} and /company/search can
+// live side by side.
+func (rt *route) parseTemplate(template string) (string, error) {
+	// a trailing :verb is part of the last segment
+	segments := strings.Split(strings.TrimPrefix(template, "/"), "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") {
+			continue
+		}
+		end := strings.Index(segment, "}")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable in %q", template)
+		}
+		variable, suffix := segment[1:end], segment[end+1:]
+
+		fieldPath, pattern := variable, "*"
+		if eq := strings.Index(variable, "="); eq >= 0 {
+			fieldPath, pattern = variable[:eq], variable[eq+1:]
+		}
+		path := strings.Split(fieldPath, ".")
+		kind, err := fieldKind(rt.requestType, path)
+		if err != nil {
+			return "", err
+		}
+
+		regexp := "[^/]+"
+		switch {
+		case strings.Contains(pattern, "**"):
+			regexp = ".+"
+		case kind >= reflect.Int && kind <= reflect.Uint64:
+			regexp = "-?[0-9]+"
+		}
+
+		name := "v" + strconv.Itoa(len(rt.pathVars))
+		rt.pathVars[name] = path
+		segments[i] = "{" + name + ":" + regexp + "}" + suffix
+	}
+	return "/" + strings.Join(segments, "/"), nil
+}
+
+func (rt *route) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	accesslog.SetRoute(r.Context(), rt.template)
+	ctx := newIncomingContext(r)
+
+	req := reflect.New(rt.requestType)
+	if err := rt.bind(r, req); err != nil {
+		encodeError(w, err)
+		return
+	}
+
+	stream := &serverTransportStream{method: rt.fullMethod}
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+	out := rt.call.Call([]reflect.Value{reflect.ValueOf(ctx), req})
+	stream.writeHeader(w)
+	if err, _ := out[1].Interface().(error); err != nil {
+		encodeError(w, err)
+		return
+	}
+
+	response := out[0].Interface()
+	if tagged, ok := response.(interface{ GetEtag() string }); ok && tagged.GetEtag() != "" {
+		w.Header().Set(etag.Header, tagged.GetEtag())
+	}
+	if rt.responseBody != "" {
+		response = selectField(out[0], rt.responseBody)
+	}
+	httpcodec.EncodeResponse(ctx, w, response)
+}
+
+// newIncomingContext builds the context a gRPC server would have seen for the
+// request: request headers as incoming metadata and the client as peer.
+// The Accept header is kept for response negotiation.
+func newIncomingContext(r *http.Request) context.Context {
+	md := metadata.MD{}
+	for key, values := range r.Header {
+		key = strings.ToLower(key)
+		if reservedHeaders[key] || strings.HasPrefix(key, "grpc-") {
+			continue
+		}
+		md[key] = append(md[key], values...)
+	}
+
+	ctx := httptransport.PopulateRequestContext(r.Context(), r)
+	ctx = metadata.NewIncomingContext(ctx, md)
+	return peer.NewContext(ctx, &peer.Peer{Addr: remoteAddr(r.RemoteAddr)})
+}
+
+// reservedHeaders are concerns of the HTTP connection rather than of the call,
+// they have no meaning as gRPC metadata
+var reservedHeaders = map[string]bool{
+	"accept-encoding":   true,
+	"connection":        true,
+	"content-encoding":  true,
+	"content-length":    true,
+	"content-type":      true,
+	"host":              true,
+	"keep-alive":        true,
+	"te":                true,
+	"trailer":           true,
+	"transfer-encoding": true,
+	"upgrade":           true,
+}
+
+type remoteAddr string
+
+func (a remoteAddr) Network() string { return "tcp" }
+func (a remoteAddr) String() string  { return string(a) }
+
+// serverTransportStream collects the headers and trailers a method sets with
+// grpc.SetHeader/grpc.SetTrailer, they are sent as HTTP response headers
+type serverTransportStream struct {
+	method string
+	mtx    sync.Mutex
+	header metadata.MD
+}
+
+func (s *serverTransportStream) Method() string {
+	return s.method
+}
+
+func (s *serverTransportStream) SetHeader(md metadata.MD) error {
+	s.mtx.Lock()
+	s.header = metadata.Join(s.header, md)
+	s.mtx.Unlock()
+	return nil
+}
+
+func (s *serverTransportStream) SendHeader(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *serverTransportStream) SetTrailer(md metadata.MD) error {
+	return s.SetHeader(md)
+}
+
+func (s *serverTransportStream) writeHeader(w http.ResponseWriter) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for key, values := range s.header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+}
+
+// encodeError writes err as a JSON error with the HTTP status matching its
+// code
+func encodeError(w http.ResponseWriter, err error) {
+	var e *apierror.Error
+	switch err.(type) {
+	case *httpcodec.DecodeError, *BindError:
+		e = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonInvalidRequest, err.Error())
+	case *httpcodec.UnsupportedMediaTypeError:
+		e = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonUnsupportedMediaType, err.Error())
+	case *httpcodec.TooLargeError:
+		e = apierror.New(codespb.Code_INVALID_ARGUMENT, httpcodec.ReasonBodyTooLarge, err.Error())
+	default:
+		e = apierror.FromError(err)
+	}
+
+	code := e.HTTPStatus()
+	switch e.Reason {
+	case ReasonUnsupportedMediaType:
+		code = http.StatusUnsupportedMediaType
+	case httpcodec.ReasonBodyTooLarge:
+		code = http.StatusRequestEntityTooLarge
+	case etag.ReasonMismatch:
+		code = http.StatusPreconditionFailed
+	}
+
+	if st, ok := status.FromError(err); ok {
+		for _, detail := range st.Details() {
+			if retry, ok := detail.(*errdetails.RetryInfo); ok && retry.GetRetryDelay() != nil {
+				// whole seconds, rounded up
+				delay := retry.GetRetryDelay()
+				seconds := delay.GetSeconds()
+				if delay.GetNanos() > 0 {
+					seconds++
+				}
+				w.Header().Set("Retry-After", strconv.FormatInt(seconds, 10))
+			}
+		}
+	}
+
+	e.WriteHTTP(w, code)
+}
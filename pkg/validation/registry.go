@@ -0,0 +1,179 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/gogo/protobuf/proto"
+
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+	"github.com/nathanows/elegant-monolith/pkg/redact"
+)
+
+// Names of the rules built on the lists of a Registry
+const (
+	RuleReservedName = "reserved_name"
+	RuleDenylist     = "denylist"
+)
+
+// Config holds the lists backing a service's reserved name and denylist
+// rules. Values are compared case insensitively.
+type Config struct {
+	// ReservedNames can't be used as names
+	ReservedNames []string
+	// Denylist holds words values may not contain
+	Denylist []string
+	// File, when set, is a JSON file of the same shape, e.g.
+	// {"reservedNames": ["admin"], "denylist": ["spam"]}. Its lists are added
+	// to the ones above.
+	File string
+}
+
+// Rule is a check of a string field a service registers with its Registry
+type Rule struct {
+	// Name is reported in field violations, e.g. reserved_name
+	Name string
+	// Description tells the caller what's wrong with the value, it follows
+	// the value in field violations, e.g. "is a reserved name"
+	Description string
+	// Valid reports whether value passes the rule
+	Valid func(value string) bool
+}
+
+// Registry holds the rules a single service checks on top of the
+// (options.rules) of its messages. Each service has its own, so rules and
+// the lists backing them don't leak from one service to another. Lists can
+// be replaced at runtime with Reload.
+type Registry struct {
+	rulesMtx sync.RWMutex
+	rules    map[string][]Rule // by field name
+
+	listsMtx sync.RWMutex
+	reserved map[string]bool
+	denylist []string
+}
+
+// NewRegistry returns a Registry with lists loaded from config
+func NewRegistry(config Config) (*Registry, error) {
+	r := &Registry{rules: map[string][]Rule{}}
+	if err := r.Reload(config); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload replaces the lists of the registry with the ones of config, reading
+// its file again. The lists are left untouched when it fails.
+func (r *Registry) Reload(config Config) error {
+	reservedNames, denylist := config.ReservedNames, config.Denylist
+	if config.File != "" {
+		b, err := ioutil.ReadFile(config.File)
+		if err != nil {
+			return fmt.Errorf("validation: %s", err)
+		}
+		var file Config
+		if err := json.Unmarshal(b, &file); err != nil {
+			return fmt.Errorf("validation: %s: %s", config.File, err)
+		}
+		reservedNames = append(append([]string(nil), reservedNames...), file.ReservedNames...)
+		denylist = append(append([]string(nil), denylist...), file.Denylist...)
+	}
+
+	reserved := make(map[string]bool, len(reservedNames))
+	for _, name := range reservedNames {
+		reserved[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	denied := make([]string, 0, len(denylist))
+	for _, word := range denylist {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			denied = append(denied, word)
+		}
+	}
+
+	r.listsMtx.Lock()
+	defer r.listsMtx.Unlock()
+	r.reserved, r.denylist = reserved, denied
+	return nil
+}
+
+// Register adds rules to the field of the given proto name
+func (r *Registry) Register(field string, rules ...Rule) {
+	r.rulesMtx.Lock()
+	defer r.rulesMtx.Unlock()
+	r.rules[field] = append(r.rules[field], rules...)
+}
+
+// ReservedName returns a rule rejecting the reserved names of the registry
+func (r *Registry) ReservedName() Rule {
+	return Rule{
+		Name:        RuleReservedName,
+		Description: "is a reserved name",
+		Valid: func(value string) bool {
+			r.listsMtx.RLock()
+			defer r.listsMtx.RUnlock()
+			return !r.reserved[strings.ToLower(strings.TrimSpace(value))]
+		},
+	}
+}
+
+// Denylist returns a rule rejecting values containing a word of the denylist
+// of the registry
+func (r *Registry) Denylist() Rule {
+	return Rule{
+		Name:        RuleDenylist,
+		Description: "contains a denied word",
+		Valid: func(value string) bool {
+			value = strings.ToLower(value)
+			r.listsMtx.RLock()
+			defer r.listsMtx.RUnlock()
+			for _, word := range r.denylist {
+				if strings.Contains(value, word) {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+// Check runs the registered rules against the string fields of msg, returning
+// every violation. Field paths are the proto names under prefix, e.g.
+// company.name. Descriptions echoing a sensitive value are masked.
+func (r *Registry) Check(msg proto.Message, prefix string) []apierror.FieldViolation {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	v = v.Elem()
+
+	r.rulesMtx.RLock()
+	defer r.rulesMtx.RUnlock()
+
+	var violations []apierror.FieldViolation
+	for i, prop := range proto.GetProperties(v.Type()).Prop {
+		checks, ok := r.rules[prop.OrigName]
+		if !ok || v.Field(i).Kind() != reflect.String {
+			continue
+		}
+		value := v.Field(i).String()
+		for _, rule := range checks {
+			if rule.Valid(value) {
+				continue
+			}
+			path := prop.OrigName
+			if prefix != "" {
+				path = prefix + "." + path
+			}
+			violations = append(violations, apierror.FieldViolation{
+				Field:       path,
+				Rule:        rule.Name,
+				Description: fmt.Sprintf("%q %s", redact.Value(msg, prop.OrigName, value), rule.Description),
+			})
+		}
+	}
+	return violations
+}
@@ -0,0 +1,295 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/asaskevich/govalidator"
+	"github.com/go-kit/kit/endpoint"
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	"github.com/gogo/protobuf/types"
+
+	codespb "github.com/nathanows/elegant-monolith/_protos/code"
+	optionspb "github.com/nathanows/elegant-monolith/_protos/options"
+	"github.com/nathanows/elegant-monolith/pkg/apierror"
+	"github.com/nathanows/elegant-monolith/pkg/fieldmask"
+)
+
+// Rule names, as reported in field violations
+const (
+	RuleRequired    = "required"
+	RuleMinLen      = "min_len"
+	RuleMaxLen      = "max_len"
+	RulePattern     = "pattern"
+	RuleEmail       = "email"
+	RuleIn          = "in"
+	RuleDefinedOnly = "defined_only"
+)
+
+// ReasonInvalidRequest is the reason of ErrInvalidRequest
+const ReasonInvalidRequest = "INVALID_REQUEST"
+
+// ErrInvalidRequest is returned by Middleware, with every field violation of
+// the request in its Fields
+var ErrInvalidRequest = apierror.New(codespb.Code_INVALID_ARGUMENT, ReasonInvalidRequest, "invalid request")
+
+// Middleware returns an endpoint middleware rejecting requests whose fields
+// break their (options.rules). In requests with an update_mask, only the
+// fields of the resource an update sets are checked.
+func Middleware() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request interface{}) (interface{}, error) {
+			if msg, ok := request.(proto.Message); ok {
+				if violations := masked(request, Validate(msg)); len(violations) > 0 {
+					return nil, ErrInvalidRequest.WithFields(violations...)
+				}
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// Validate checks msg and the messages nested in it against the
+// (options.rules) of their fields, returning every violation. Field paths use
+// proto names, e.g. company.name or api_key.scopes[1].
+func Validate(msg proto.Message) []apierror.FieldViolation {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return nil
+	}
+	return validateMessage(v.Elem(), "")
+}
+
+// masked drops the violations of resource fields left out of the
+// update_mask of request. Paths of the mask are relative to the resource,
+// the message field of the request the violations are nested in.
+func masked(request interface{}, violations []apierror.FieldViolation) []apierror.FieldViolation {
+	r, ok := request.(interface{ GetUpdateMask() *types.FieldMask })
+	if !ok || len(violations) == 0 {
+		return violations
+	}
+	paths := r.GetUpdateMask().GetPaths()
+	if len(paths) == 0 || contains(paths, fieldmask.Wildcard) {
+		return violations
+	}
+	var kept []apierror.FieldViolation
+	for _, violation := range violations {
+		dot := strings.Index(violation.Field, ".")
+		if dot < 0 || fieldmask.Covers(paths, violation.Field[dot+1:]) {
+			kept = append(kept, violation)
+		}
+	}
+	return kept
+}
+
+// fieldRules are the rules of a single field of a message struct
+type fieldRules struct {
+	index   int
+	name    string
+	rules   *optionspb.FieldRules
+	pattern *regexp.Regexp
+	enum    map[int32]bool // declared values, for defined_only
+	nested  bool           // holds messages to walk
+}
+
+// the rules of message types, read from their descriptors
+var (
+	cacheMtx sync.RWMutex
+	cache    = map[reflect.Type][]*fieldRules{}
+)
+
+func validateMessage(s reflect.Value, prefix string) []apierror.FieldViolation {
+	var violations []apierror.FieldViolation
+	for _, f := range lookup(s.Type()) {
+		field := s.Field(f.index)
+		path := f.name
+		if prefix != "" {
+			path = prefix + "." + f.name
+		}
+
+		if f.rules != nil {
+			if f.rules.GetRequired() && isZero(field) {
+				violations = append(violations, apierror.FieldViolation{Field: path, Rule: RuleRequired, Description: "is required"})
+				continue
+			}
+			if field.Kind() == reflect.Slice && field.Type().Elem().Kind() != reflect.Uint8 {
+				for i := 0; i < field.Len(); i++ {
+					violations = append(violations, f.check(field.Index(i), path+"["+strconv.Itoa(i)+"]")...)
+				}
+			} else {
+				violations = append(violations, f.check(field, path)...)
+			}
+		}
+
+		if !f.nested {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.Ptr:
+			if !field.IsNil() {
+				violations = append(violations, validateMessage(field.Elem(), path)...)
+			}
+		case reflect.Slice:
+			for i := 0; i < field.Len(); i++ {
+				if elem := field.Index(i); !elem.IsNil() {
+					violations = append(violations, validateMessage(elem.Elem(), path+"["+strconv.Itoa(i)+"]")...)
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// check applies the rules other than required to a single value, zero values
+// are left to required
+func (f *fieldRules) check(v reflect.Value, path string) []apierror.FieldViolation {
+	if isZero(v) {
+		return nil
+	}
+	var violations []apierror.FieldViolation
+	violate := func(rule, format string, args ...interface{}) {
+		violations = append(violations, apierror.FieldViolation{Field: path, Rule: rule, Description: fmt.Sprintf(format, args...)})
+	}
+
+	rules := f.rules
+	switch v.Kind() {
+	case reflect.String:
+		str := v.String()
+		length := uint32(utf8.RuneCountInString(str))
+		if rules.GetMinLen() > 0 && length < rules.GetMinLen() {
+			violate(RuleMinLen, "must be at least %d characters", rules.GetMinLen())
+		}
+		if rules.GetMaxLen() > 0 && length > rules.GetMaxLen() {
+			violate(RuleMaxLen, "must be at most %d characters", rules.GetMaxLen())
+		}
+		if f.pattern != nil && !f.pattern.MatchString(str) {
+			violate(RulePattern, "must match %s", rules.GetPattern())
+		}
+		if rules.GetEmail() && !govalidator.IsEmail(str) {
+			violate(RuleEmail, "must be an email address")
+		}
+		if len(rules.GetIn()) > 0 && !contains(rules.GetIn(), str) {
+			violate(RuleIn, "must be one of %s", strings.Join(rules.GetIn(), ", "))
+		}
+	case reflect.Int32:
+		if f.enum != nil && !f.enum[int32(v.Int())] {
+			violate(RuleDefinedOnly, "must be a defined value")
+		}
+	}
+	return violations
+}
+
+// lookup returns the rules of a message struct type, reading them from its
+// descriptor the first time
+func lookup(t reflect.Type) []*fieldRules {
+	cacheMtx.RLock()
+	f, ok := cache[t]
+	cacheMtx.RUnlock()
+	if ok {
+		return f
+	}
+
+	var md *descriptor.DescriptorProto
+	if msg, ok := reflect.New(t).Interface().(descriptor.Message); ok {
+		_, md = descriptor.ForMessage(msg)
+	}
+
+	var all []*fieldRules
+	for i, prop := range proto.GetProperties(t).Prop {
+		field := t.Field(i)
+		if strings.HasPrefix(field.Name, "XXX_") {
+			continue
+		}
+		f := &fieldRules{index: i, name: prop.OrigName}
+
+		ft := field.Type
+		if ft.Kind() == reflect.Slice {
+			ft = ft.Elem()
+		}
+		f.nested = ft.Kind() == reflect.Ptr && ft.Elem().Kind() == reflect.Struct
+
+		if fd := fieldDescriptor(md, prop.OrigName); fd != nil {
+			f.rules = rulesOf(fd)
+			if f.rules.GetPattern() != "" {
+				// an invalid pattern in a proto file is a programming error
+				f.pattern = regexp.MustCompile(f.rules.GetPattern())
+			}
+			if f.rules.GetDefinedOnly() && fd.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM {
+				f.enum = map[int32]bool{}
+				for _, value := range proto.EnumValueMap(strings.TrimPrefix(fd.GetTypeName(), ".")) {
+					f.enum[value] = true
+				}
+			}
+		}
+		if f.rules != nil || f.nested {
+			all = append(all, f)
+		}
+	}
+
+	cacheMtx.Lock()
+	defer cacheMtx.Unlock()
+	if existing, ok := cache[t]; ok {
+		return existing
+	}
+	cache[t] = all
+	return all
+}
+
+func fieldDescriptor(md *descriptor.DescriptorProto, name string) *descriptor.FieldDescriptorProto {
+	if md == nil {
+		return nil
+	}
+	for _, field := range md.GetField() {
+		if field.GetName() == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// rulesOf returns the (options.rules) of a field, nil when it has none
+func rulesOf(fd *descriptor.FieldDescriptorProto) *optionspb.FieldRules {
+	if fd.GetOptions() == nil {
+		return nil
+	}
+	ext, err := proto.GetExtension(fd.GetOptions(), optionspb.E_Rules)
+	if err != nil {
+		return nil
+	}
+	rules, _ := ext.(*optionspb.FieldRules)
+	return rules
+}
+
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}